@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/docker"
+	tastoraclient "github.com/celestiaorg/tastora/framework/docker/client"
+	"github.com/celestiaorg/tastora/framework/docker/consts"
+	"github.com/celestiaorg/tastora/framework/testutil/topology"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+)
+
+// runUp creates and starts an environment from a topology file and records it in a state file.
+func runUp(ctx context.Context, args []string) error {
+	fs, ef := newFlagSet("up")
+	file := fs.String("f", "", "path of the topology file (YAML or JSON)")
+	_ = fs.Parse(args)
+
+	if *file == "" {
+		return errors.New("a topology file must be provided with -f")
+	}
+	if _, err := os.Stat(statePath(ef.stateDir, ef.name)); err == nil {
+		return fmt.Errorf("environment %q already exists, run down first or choose another -name", ef.name)
+	}
+
+	topologyPath, err := filepath.Abs(*file)
+	if err != nil {
+		return err
+	}
+	spec, err := topology.LoadFile(topologyPath)
+	if err != nil {
+		return err
+	}
+
+	logger, err := newLogger()
+	if err != nil {
+		return err
+	}
+	// cleanups registered by docker.Setup are only run if the environment fails to start,
+	// otherwise the environment outlives this process and is removed with down.
+	t := types.NewStandaloneT(ef.name, logger)
+
+	dockerClient, networkID, err := setup(t)
+	if err != nil {
+		return err
+	}
+
+	state := &State{
+		Name:         ef.name,
+		Topology:     topologyPath,
+		CleanupLabel: dockerClient.CleanupLabel(),
+		NetworkID:    networkID,
+		CreatedAt:    time.Now().UTC(),
+	}
+	// the state is written before anything is started so that down can always remove the resources.
+	if err := saveState(ef.stateDir, state); err != nil {
+		return err
+	}
+
+	env, err := topology.Build(ctx, t, topology.Config{
+		Logger:          logger,
+		DockerClient:    dockerClient,
+		DockerNetworkID: networkID,
+		TestName:        ef.name,
+	}, spec)
+	if err != nil {
+		logger.Error("failed to start environment, removing it", zap.Error(err))
+		t.Close()
+		return errors.Join(err, removeState(ef.stateDir, ef.name))
+	}
+
+	state.Components = components(env)
+	if err := saveState(ef.stateDir, state); err != nil {
+		return err
+	}
+
+	fmt.Printf("environment %q is up, run 'tastora status -name %s' to list its containers\n", ef.name, ef.name)
+	return nil
+}
+
+// setup creates the docker client and network of a new environment.
+func setup(t *types.StandaloneT) (cli types.TastoraDockerClient, networkID string, err error) {
+	// docker.Setup panics on failure as a test cannot continue without docker.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("docker setup failed: %v", r)
+		}
+	}()
+	cli, networkID = docker.Setup(t)
+	return cli, networkID, nil
+}
+
+// components maps every component of the environment to the names of its containers.
+func components(env *topology.Environment) map[string][]string {
+	out := map[string][]string{}
+	for name, chain := range env.Chains {
+		for _, n := range chain.Nodes() {
+			out[name] = append(out[name], n.Name())
+		}
+	}
+	for name, network := range env.DANetworks {
+		for _, n := range network.GetNodes() {
+			out[name] = append(out[name], n.Name())
+		}
+	}
+	for name, n := range env.Reth {
+		out[name] = append(out[name], n.Name())
+	}
+	for name, chain := range env.EVMChains {
+		for _, n := range chain.Nodes() {
+			out[name] = append(out[name], n.Name())
+		}
+	}
+	for name, r := range env.Relayers {
+		out[name] = append(out[name], r.Name())
+	}
+	return out
+}
+
+// runDown removes every docker resource of an environment along with its state file.
+func runDown(_ context.Context, args []string) error {
+	fs, ef := newFlagSet("down")
+	_ = fs.Parse(args)
+
+	state, err := loadState(ef.stateDir, ef.name)
+	if err != nil {
+		return err
+	}
+	logger, err := newLogger()
+	if err != nil {
+		return err
+	}
+	dockerClient, err := newDockerClient(state)
+	if err != nil {
+		return err
+	}
+
+	docker.CleanupWithLabel(types.NewStandaloneT(ef.name, logger), dockerClient, state.CleanupLabel)()
+
+	if err := removeState(ef.stateDir, ef.name); err != nil {
+		return err
+	}
+	fmt.Printf("environment %q is down\n", ef.name)
+	return nil
+}
+
+// runStatus prints every container of an environment.
+func runStatus(ctx context.Context, args []string) error {
+	fs, ef := newFlagSet("status")
+	_ = fs.Parse(args)
+
+	state, err := loadState(ef.stateDir, ef.name)
+	if err != nil {
+		return err
+	}
+	dockerClient, err := newDockerClient(state)
+	if err != nil {
+		return err
+	}
+	containers, err := listContainers(ctx, dockerClient, state.CleanupLabel)
+	if err != nil {
+		return err
+	}
+
+	owners := map[string]string{}
+	for component, names := range state.Components {
+		for _, n := range names {
+			owners[n] = component
+		}
+	}
+
+	fmt.Printf("environment: %s\ntopology:    %s\ncreated:     %s\n\n", state.Name, state.Topology, state.CreatedAt.Format(time.RFC3339))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "COMPONENT\tCONTAINER\tSTATE\tSTATUS\tPORTS")
+	for _, c := range containers {
+		name := containerName(c)
+		component := owners[name]
+		if component == "" {
+			component = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", component, name, c.State, c.Status, formatPorts(c.Ports))
+	}
+	return w.Flush()
+}
+
+// runLogs prints the logs of a container of an environment.
+func runLogs(ctx context.Context, args []string) error {
+	fs, ef := newFlagSet("logs")
+	follow := fs.Bool("follow", false, "follow the log output")
+	tail := fs.String("tail", "all", "number of lines to show from the end of the logs")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("exactly one container or component must be provided")
+	}
+
+	state, err := loadState(ef.stateDir, ef.name)
+	if err != nil {
+		return err
+	}
+	dockerClient, err := newDockerClient(state)
+	if err != nil {
+		return err
+	}
+	containerID, err := resolveContainer(ctx, dockerClient, state, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	rc, err := dockerClient.ContainerLogs(ctx, containerID, client.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     *follow,
+		Tail:       *tail,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get container logs: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	if _, err := stdcopy.StdCopy(os.Stdout, os.Stderr, rc); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("failed to read container logs: %w", err)
+	}
+	return nil
+}
+
+// runExec runs a command in a container of an environment and exits with its exit code.
+func runExec(ctx context.Context, args []string) error {
+	fs, ef := newFlagSet("exec")
+	user := fs.String("user", "", "user to run the command as, defaults to the container user")
+	_ = fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		return errors.New("a container or component and a command must be provided")
+	}
+
+	state, err := loadState(ef.stateDir, ef.name)
+	if err != nil {
+		return err
+	}
+	dockerClient, err := newDockerClient(state)
+	if err != nil {
+		return err
+	}
+	containerID, err := resolveContainer(ctx, dockerClient, state, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	exec, err := dockerClient.ExecCreate(ctx, containerID, client.ExecCreateOptions{
+		User:         *user,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          fs.Args()[1:],
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create exec: %w", err)
+	}
+
+	attach, err := dockerClient.ExecAttach(ctx, exec.ID, client.ExecAttachOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to attach to exec: %w", err)
+	}
+	defer attach.Close()
+
+	if _, err := stdcopy.StdCopy(os.Stdout, os.Stderr, attach.Reader); err != nil {
+		return fmt.Errorf("failed to read exec output: %w", err)
+	}
+
+	inspect, err := dockerClient.ExecInspect(ctx, exec.ID, client.ExecInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to inspect exec: %w", err)
+	}
+	if inspect.ExitCode != 0 {
+		return exitCodeError(inspect.ExitCode)
+	}
+	return nil
+}
+
+// newDockerClient returns a docker client associated with the cleanup label of the environment.
+func newDockerClient(state *State) (types.TastoraDockerClient, error) {
+	cli, err := client.New(client.FromEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	return tastoraclient.NewClient(cli, state.CleanupLabel), nil
+}
+
+// listContainers returns every container with the given cleanup label sorted by name.
+func listContainers(ctx context.Context, cli types.TastoraDockerClient, cleanupLabel string) ([]container.Summary, error) {
+	res, err := cli.ContainerList(ctx, client.ContainerListOptions{
+		All:     true,
+		Filters: make(client.Filters).Add("label", consts.CleanupLabel+"="+cleanupLabel),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	containers := res.Items
+	sort.Slice(containers, func(i, j int) bool {
+		return containerName(containers[i]) < containerName(containers[j])
+	})
+	return containers, nil
+}
+
+// resolveContainer returns the ID of the container of the environment identified by target, which is
+// either the name of a container or the name of a component with a single container.
+func resolveContainer(ctx context.Context, cli types.TastoraDockerClient, state *State, target string) (string, error) {
+	name := target
+	if names, ok := state.Components[target]; ok {
+		if len(names) != 1 {
+			return "", fmt.Errorf("component %q has %d containers, specify one of: %s", target, len(names), strings.Join(names, ", "))
+		}
+		name = names[0]
+	}
+
+	containers, err := listContainers(ctx, cli, state.CleanupLabel)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range containers {
+		if containerName(c) == name {
+			return c.ID, nil
+		}
+	}
+	return "", fmt.Errorf("container %q not found in environment %q", target, state.Name)
+}
+
+// containerName returns the name of the container without the leading slash.
+func containerName(c container.Summary) string {
+	if len(c.Names) == 0 {
+		return c.ID
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+// formatPorts returns the published ports of a container as host:container pairs.
+func formatPorts(ports []container.PortSummary) string {
+	var out []string
+	for _, p := range ports {
+		if p.PublicPort == 0 {
+			continue
+		}
+		out = append(out, strconv.Itoa(int(p.PublicPort))+"->"+strconv.Itoa(int(p.PrivatePort)))
+	}
+	sort.Strings(out)
+	return strings.Join(out, ",")
+}
@@ -0,0 +1,114 @@
+// Command tastora brings up and tears down environments described by topology files outside of go test.
+//
+// Usage:
+//
+//	tastora up -f topology.yaml [-name NAME]
+//	tastora status [-name NAME]
+//	tastora logs [-name NAME] [-follow] [-tail N] CONTAINER
+//	tastora exec [-name NAME] CONTAINER COMMAND [ARGS...]
+//	tastora down [-name NAME]
+//
+// The resources of every environment are recorded in a state file within the state directory,
+// which allows down to remove them by their cleanup label once up has exited.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultName     = "tastora"
+	defaultStateDir = ".tastora"
+)
+
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{name: "up", summary: "create and start an environment from a topology file", run: runUp},
+	{name: "down", summary: "stop and remove an environment", run: runDown},
+	{name: "status", summary: "show the containers of an environment", run: runStatus},
+	{name: "logs", summary: "print the logs of a container", run: runLogs},
+	{name: "exec", summary: "run a command in a container", run: runExec},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+		if err := c.run(ctx, os.Args[2:]); err != nil {
+			var exitErr exitCodeError
+			if errors.As(err, &exitErr) {
+				os.Exit(int(exitErr))
+			}
+			fmt.Fprintf(os.Stderr, "tastora %s: %v\n", c.name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: tastora <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run 'tastora <command> -h' for the flags of a command.")
+}
+
+// envFlags are the flags shared by every command identifying the environment.
+type envFlags struct {
+	name     string
+	stateDir string
+}
+
+// newFlagSet returns a flag set for the named command with the shared environment flags registered.
+func newFlagSet(name string) (*flag.FlagSet, *envFlags) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	ef := &envFlags{}
+	fs.StringVar(&ef.name, "name", defaultName, "name of the environment")
+	fs.StringVar(&ef.stateDir, "state-dir", defaultStateDir, "directory holding the environment state files")
+	return fs, ef
+}
+
+// newLogger returns a human readable logger writing to stderr.
+func newLogger() (*zap.Logger, error) {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	cfg.DisableStacktrace = true
+	return cfg.Build()
+}
+
+// exitCodeError propagates the exit code of a command run with exec.
+type exitCodeError int
+
+func (e exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
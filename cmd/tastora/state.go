@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State is persisted by up so that later invocations can find the resources of an environment.
+type State struct {
+	// Name is the name of the environment.
+	Name string `json:"name"`
+	// Topology is the absolute path of the topology file the environment was created from.
+	Topology string `json:"topology"`
+	// CleanupLabel is the label attached to every docker resource of the environment.
+	CleanupLabel string `json:"cleanup_label"`
+	// NetworkID is the ID of the docker network the environment is deployed to.
+	NetworkID string `json:"network_id"`
+	// CreatedAt is the time the environment was created.
+	CreatedAt time.Time `json:"created_at"`
+	// Components maps the name of every component in the topology to the names of its containers.
+	Components map[string][]string `json:"components,omitempty"`
+}
+
+// statePath returns the path of the state file of the named environment.
+func statePath(stateDir, name string) string {
+	return filepath.Join(stateDir, name+".json")
+}
+
+// saveState writes the state file of the environment.
+func saveState(stateDir string, s *State) error {
+	if err := os.MkdirAll(stateDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	bz, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if err := os.WriteFile(statePath(stateDir, s.Name), bz, 0o644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// loadState reads the state file of the named environment.
+func loadState(stateDir, name string) (*State, error) {
+	bz, err := os.ReadFile(statePath(stateDir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("environment %q not found in %s, was it created with up?", name, stateDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	return &s, nil
+}
+
+// removeState deletes the state file of the named environment.
+func removeState(stateDir, name string) error {
+	if err := os.Remove(statePath(stateDir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := loadState(dir, "missing")
+	require.ErrorContains(t, err, "not found")
+
+	state := &State{
+		Name:         "dev",
+		Topology:     "/tmp/topology.yaml",
+		CleanupLabel: "dev-abcdefgh",
+		NetworkID:    "network",
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+		Components: map[string][]string{
+			"celestia": {"test-val-0-dev", "test-val-1-dev"},
+		},
+	}
+	require.NoError(t, saveState(dir, state))
+
+	loaded, err := loadState(dir, "dev")
+	require.NoError(t, err)
+	require.Equal(t, state, loaded)
+
+	require.NoError(t, removeState(dir, "dev"))
+	require.NoError(t, removeState(dir, "dev"), "removing a missing state file should not fail")
+
+	_, err = loadState(dir, "dev")
+	require.Error(t, err)
+}
@@ -0,0 +1,344 @@
+// Package artifacts collects debugging artifacts of a test's docker resources into a directory,
+// so that they can be uploaded by CI when a test fails.
+//
+// For every container with the test's cleanup label the collector writes the full container logs
+// and the output of docker inspect. Nodes registered as a Source contribute additional files such
+// as their config files and current height. The docker events of the test's resources are written
+// as a structured event log, one JSON object per line.
+//
+// The resulting layout is:
+//
+//	<dir>/<test name>/
+//	  summary.json
+//	  events.jsonl
+//	  containers/<container name>/logs.txt
+//	  containers/<container name>/inspect.json
+//	  containers/<container name>/<source files...>
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/docker/consts"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+)
+
+const (
+	// EnvDir is the environment variable holding the directory artifacts are written to.
+	// Artifacts are not collected when it is unset.
+	EnvDir = "TASTORA_ARTIFACTS_DIR"
+	// EnvAlways is the environment variable which, when set to a non-empty value, collects
+	// artifacts for tests which passed as well.
+	EnvAlways = "TASTORA_ARTIFACTS_ALWAYS"
+)
+
+// Source is a node which contributes artifacts in addition to the logs and inspect output of its container.
+type Source interface {
+	// Name returns the name of the node's container, artifacts are written to the directory of that container.
+	Name() string
+	// Artifacts returns the files to write keyed by file name. Files which could be produced are
+	// returned alongside an error describing the ones which could not.
+	Artifacts(ctx context.Context) (map[string][]byte, error)
+}
+
+var (
+	registryMu sync.Mutex
+	// registry maps a docker client cleanup label to the sources created with that client.
+	registry = map[string][]Source{}
+)
+
+// Register associates the source with the given cleanup label so that its artifacts are
+// collected along with the containers of that label.
+func Register(cleanupLabel string, s Source) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[cleanupLabel] = append(registry[cleanupLabel], s)
+}
+
+// Forget removes every source associated with the given cleanup label. It is invoked by docker.CleanupWithLabel.
+func Forget(cleanupLabel string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, cleanupLabel)
+}
+
+// ForgetOnCleanup removes every source associated with the given cleanup label once t and its subtests have
+// completed. It is registered by docker.Setup before its own cleanup, so that the sources are forgotten after
+// their artifacts are collected, even if the docker cleanup does not run to completion.
+func ForgetOnCleanup(t interface{ Cleanup(func()) }, cleanupLabel string) {
+	t.Cleanup(func() {
+		Forget(cleanupLabel)
+	})
+}
+
+// sources returns the sources associated with the given cleanup label keyed by container name.
+func sources(cleanupLabel string) map[string]Source {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	out := make(map[string]Source, len(registry[cleanupLabel]))
+	for _, s := range registry[cleanupLabel] {
+		out[s.Name()] = s
+	}
+	return out
+}
+
+// Dir returns the directory artifacts should be written to for a test with the given outcome,
+// or an empty string if they should not be collected.
+func Dir(testFailed bool) string {
+	dir := os.Getenv(EnvDir)
+	if dir == "" {
+		return ""
+	}
+	if !testFailed && os.Getenv(EnvAlways) == "" {
+		return ""
+	}
+	return dir
+}
+
+// Summary describes the artifacts collected for a test and is written to summary.json.
+type Summary struct {
+	Test        string             `json:"test"`
+	Failed      bool               `json:"failed"`
+	CollectedAt time.Time          `json:"collected_at"`
+	Containers  []ContainerSummary `json:"containers"`
+	// Errors lists every artifact which could not be collected.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ContainerSummary describes a container of the test at the time artifacts were collected.
+type ContainerSummary struct {
+	Name   string `json:"name"`
+	ID     string `json:"id"`
+	Image  string `json:"image"`
+	State  string `json:"state"`
+	Status string `json:"status"`
+	// Files lists the artifacts written for the container relative to its directory.
+	Files []string `json:"files"`
+}
+
+// Collect writes the artifacts of every container with the given cleanup label to a directory named
+// after the test within dir, and returns the path of that directory. Collection continues past
+// individual failures, which are recorded in the summary and returned joined together.
+func Collect(ctx context.Context, cli types.TastoraDockerClient, cleanupLabel, dir, testName string, testFailed bool) (string, error) {
+	testDir := filepath.Join(dir, sanitizePath(testName))
+	if err := os.MkdirAll(testDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+
+	cs, err := cli.ContainerList(ctx, client.ContainerListOptions{
+		All:     true,
+		Filters: make(client.Filters).Add("label", consts.CleanupLabel+"="+cleanupLabel),
+	})
+	if err != nil {
+		return testDir, fmt.Errorf("failed to list containers: %w", err)
+	}
+	containers := cs.Items
+	sort.Slice(containers, func(i, j int) bool {
+		return containerName(containers[i]) < containerName(containers[j])
+	})
+
+	summary := Summary{
+		Test:        testName,
+		Failed:      testFailed,
+		CollectedAt: time.Now().UTC(),
+	}
+	var errs []error
+
+	srcs := sources(cleanupLabel)
+	for _, c := range containers {
+		cs, err := collectContainer(ctx, cli, testDir, c, srcs[containerName(c)])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		summary.Containers = append(summary.Containers, cs)
+	}
+
+	if err := writeEvents(ctx, cli, filepath.Join(testDir, "events.jsonl"), cleanupLabel, earliestCreated(containers), summary.CollectedAt); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, err := range errs {
+		summary.Errors = append(summary.Errors, err.Error())
+	}
+	if err := writeJSON(filepath.Join(testDir, "summary.json"), summary); err != nil {
+		errs = append(errs, err)
+	}
+
+	return testDir, errors.Join(errs...)
+}
+
+// collectContainer writes the logs, inspect output and source artifacts of a single container.
+func collectContainer(ctx context.Context, cli types.TastoraDockerClient, testDir string, c container.Summary, src Source) (ContainerSummary, error) {
+	name := containerName(c)
+	cs := ContainerSummary{
+		Name:   name,
+		ID:     c.ID,
+		Image:  c.Image,
+		State:  string(c.State),
+		Status: c.Status,
+	}
+
+	containerDir := filepath.Join(testDir, "containers", sanitizePath(name))
+	if err := os.MkdirAll(containerDir, 0o755); err != nil {
+		return cs, fmt.Errorf("failed to create artifacts directory for %s: %w", name, err)
+	}
+
+	var errs []error
+	if err := writeLogs(ctx, cli, filepath.Join(containerDir, "logs.txt"), c.ID); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	} else {
+		cs.Files = append(cs.Files, "logs.txt")
+	}
+
+	if err := writeInspect(ctx, cli, filepath.Join(containerDir, "inspect.json"), c.ID); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	} else {
+		cs.Files = append(cs.Files, "inspect.json")
+	}
+
+	if src != nil {
+		files, err := src.Artifacts(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		fileNames := make([]string, 0, len(files))
+		for fileName := range files {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+		for _, fileName := range fileNames {
+			base := sanitizePath(fileName)
+			if err := os.WriteFile(filepath.Join(containerDir, base), files[fileName], 0o644); err != nil {
+				errs = append(errs, fmt.Errorf("%s: failed to write %s: %w", name, base, err))
+				continue
+			}
+			cs.Files = append(cs.Files, base)
+		}
+	}
+
+	return cs, errors.Join(errs...)
+}
+
+// writeLogs writes the full stdout and stderr of the container, with timestamps, to path.
+func writeLogs(ctx context.Context, cli types.TastoraDockerClient, path, containerID string) error {
+	rc, err := cli.ContainerLogs(ctx, containerID, client.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get container logs: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create log file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := stdcopy.StdCopy(f, f, rc); err != nil {
+		return fmt.Errorf("failed to read container logs: %w", err)
+	}
+	return nil
+}
+
+// writeInspect writes the raw docker inspect output of the container to path.
+func writeInspect(ctx context.Context, cli types.TastoraDockerClient, path, containerID string) error {
+	res, err := cli.ContainerInspect(ctx, containerID, client.ContainerInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return writeJSON(path, res.Raw)
+}
+
+// writeEvents writes the docker events of resources with the cleanup label between since and until to path,
+// one JSON object per line.
+func writeEvents(ctx context.Context, cli types.TastoraDockerClient, path, cleanupLabel string, since, until time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	res := cli.Events(ctx, client.EventsListOptions{
+		Since:   strconv.FormatInt(since.Unix(), 10),
+		Until:   strconv.FormatInt(until.Unix()+1, 10),
+		Filters: make(client.Filters).Add("label", consts.CleanupLabel+"="+cleanupLabel),
+	})
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create event log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	enc := json.NewEncoder(f)
+	for {
+		select {
+		case msg := <-res.Messages:
+			if err := enc.Encode(msg); err != nil {
+				return fmt.Errorf("failed to write event: %w", err)
+			}
+		case err := <-res.Err:
+			if err == nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read docker events: %w", err)
+		}
+	}
+}
+
+// writeJSON writes v to path as indented JSON.
+func writeJSON(path string, v any) error {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, bz, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// earliestCreated returns the creation time of the oldest container, or the current time if there are none.
+func earliestCreated(containers []container.Summary) time.Time {
+	earliest := time.Now()
+	for _, c := range containers {
+		if created := time.Unix(c.Created, 0); created.Before(earliest) {
+			earliest = created
+		}
+	}
+	return earliest
+}
+
+// containerName returns the name of the container without the leading slash.
+func containerName(c container.Summary) string {
+	if len(c.Names) == 0 {
+		return c.ID
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// sanitizePath turns s into a single path element, e.g. "TestFoo/sub case" becomes "TestFoo_sub_case".
+func sanitizePath(s string) string {
+	s = unsafePathChars.ReplaceAllString(s, "_")
+	if s == "" || s == "." || s == ".." {
+		return "_"
+	}
+	return s
+}
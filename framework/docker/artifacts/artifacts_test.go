@@ -0,0 +1,74 @@
+package artifacts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct{ name string }
+
+func (f fakeSource) Name() string { return f.name }
+
+func (f fakeSource) Artifacts(context.Context) (map[string][]byte, error) { return nil, nil }
+
+func TestDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		dir        string
+		always     string
+		testFailed bool
+		expected   string
+	}{
+		{name: "unset", testFailed: true, expected: ""},
+		{name: "failed", dir: "/tmp/artifacts", testFailed: true, expected: "/tmp/artifacts"},
+		{name: "passed", dir: "/tmp/artifacts", testFailed: false, expected: ""},
+		{name: "passed always", dir: "/tmp/artifacts", always: "1", testFailed: false, expected: "/tmp/artifacts"},
+		{name: "always without dir", always: "1", testFailed: false, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvDir, tt.dir)
+			t.Setenv(EnvAlways, tt.always)
+			require.Equal(t, tt.expected, Dir(tt.testFailed))
+		})
+	}
+}
+
+func TestSanitizePath(t *testing.T) {
+	require.Equal(t, "TestFoo_sub_case", sanitizePath("TestFoo/sub case"))
+	require.Equal(t, "celestia-val-0-TestFoo", sanitizePath("celestia-val-0-TestFoo"))
+	require.Equal(t, "_", sanitizePath(".."))
+	require.Equal(t, "_", sanitizePath(""))
+}
+
+func TestRegistry(t *testing.T) {
+	Register("label-a", fakeSource{name: "node-0"})
+	Register("label-a", fakeSource{name: "node-1"})
+	Register("label-b", fakeSource{name: "node-2"})
+
+	srcs := sources("label-a")
+	require.Len(t, srcs, 2)
+	require.Contains(t, srcs, "node-0")
+	require.Contains(t, srcs, "node-1")
+
+	Forget("label-a")
+	require.Empty(t, sources("label-a"))
+	require.Len(t, sources("label-b"), 1)
+	Forget("label-b")
+}
+
+func TestForgetOnCleanup(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		ForgetOnCleanup(t, "label-c")
+		Register("label-c", fakeSource{name: "node-0"})
+		Register("label-c", fakeSource{name: "node-1"})
+		require.Len(t, sources("label-c"), 2)
+	})
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	require.NotContains(t, registry, "label-c", "sources should be forgotten once the test completes")
+}
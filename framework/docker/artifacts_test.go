@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celestiaorg/tastora/framework/docker/artifacts"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCollectArtifacts verifies that logs, inspect output and node files are collected for every container.
+func TestCollectArtifacts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+	testDir, err := artifacts.Collect(testCfg.Ctx, testCfg.DockerClient, testCfg.DockerClient.CleanupLabel(), t.TempDir(), t.Name(), true)
+	require.NoError(t, err)
+
+	bz, err := os.ReadFile(filepath.Join(testDir, "summary.json"))
+	require.NoError(t, err)
+	var summary artifacts.Summary
+	require.NoError(t, json.Unmarshal(bz, &summary))
+	require.Equal(t, t.Name(), summary.Test)
+	require.True(t, summary.Failed)
+	require.Empty(t, summary.Errors)
+
+	node := chain.GetNodes()[0]
+	var names []string
+	for _, c := range summary.Containers {
+		names = append(names, c.Name)
+	}
+	require.Contains(t, names, node.Name())
+
+	containerDir := filepath.Join(testDir, "containers", node.Name())
+	for _, f := range []string{"logs.txt", "inspect.json", "config.toml", "app.toml", "genesis.json", "status.json"} {
+		require.FileExists(t, filepath.Join(containerDir, f))
+	}
+
+	logs, err := os.ReadFile(filepath.Join(containerDir, "logs.txt"))
+	require.NoError(t, err)
+	require.NotEmpty(t, logs)
+
+	events, err := os.ReadFile(filepath.Join(testDir, "events.jsonl"))
+	require.NoError(t, err)
+	require.Contains(t, string(events), `"Action":"start"`)
+}
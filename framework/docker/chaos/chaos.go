@@ -0,0 +1,299 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/celestiaorg/tastora/framework/docker/consts"
+	"github.com/celestiaorg/tastora/framework/docker/container"
+	"github.com/celestiaorg/tastora/framework/docker/dataavailability"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/containerd/errdefs"
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+)
+
+// Target is a node whose container network can be manipulated.
+// cosmos.ChainNode and dataavailability.Node both satisfy this interface.
+type Target interface {
+	types.NetworkInfoProvider
+	// Name returns the name of the node's container.
+	Name() string
+	// ContainerID returns the ID of the node's container.
+	ContainerID() string
+}
+
+// TargetsFromChainNodes converts chain nodes into Targets, returning an error if any node is not backed by a container.
+func TargetsFromChainNodes(nodes []types.ChainNode) ([]Target, error) {
+	targets := make([]Target, 0, len(nodes))
+	for i, n := range nodes {
+		t, ok := n.(Target)
+		if !ok {
+			return nil, fmt.Errorf("chain node %d (%T) cannot be used as a chaos target", i, n)
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// TargetsFromDANodes converts DA nodes into Targets.
+func TargetsFromDANodes(nodes []*dataavailability.Node) []Target {
+	targets := make([]Target, 0, len(nodes))
+	for _, n := range nodes {
+		targets = append(targets, n)
+	}
+	return targets
+}
+
+// Config contains the configuration for an Injector.
+type Config struct {
+	// Logger is the logger instance used for all operations.
+	Logger *zap.Logger
+	// DockerClient is the docker client instance.
+	DockerClient types.TastoraDockerClient
+	// DockerNetworkID is the ID of the docker network the targets are deployed to.
+	DockerNetworkID string
+	// Image provides the tc and iptables binaries. Defaults to DefaultImage().
+	Image container.Image
+}
+
+// DefaultImage returns the image used to manipulate container networks.
+func DefaultImage() container.Image {
+	return container.NewImage("nicolaka/netshoot", "v0.13", consts.UserRootString)
+}
+
+// Injector applies network faults to nodes on a docker network and records them so they can be healed.
+//
+// Faults are applied from short-lived sidecar containers which join the target container's network
+// namespace, so the target images do not need to ship any networking tools.
+// Every Injector is registered against the cleanup label of its docker client, and any faults which
+// are still applied when docker.Setup's cleanup runs are healed before the containers are torn down.
+type Injector struct {
+	cfg      Config
+	log      *zap.Logger
+	testName string
+
+	mu     sync.Mutex
+	faults []Fault
+}
+
+// NewInjector returns a new Injector and registers it for healing during cleanup.
+func NewInjector(cfg Config, testName string) *Injector {
+	if cfg.Image.Repository == "" {
+		cfg.Image = DefaultImage()
+	}
+	i := &Injector{
+		cfg:      cfg,
+		log:      cfg.Logger.With(zap.String("component", "chaos")),
+		testName: testName,
+	}
+	register(cfg.DockerClient.CleanupLabel(), i)
+	return i
+}
+
+// Faults returns a copy of all faults currently applied by the Injector.
+func (i *Injector) Faults() []Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]Fault(nil), i.faults...)
+}
+
+// Partition drops all traffic between every node in groupA and every node in groupB.
+// Traffic within each group is unaffected.
+func (i *Injector) Partition(ctx context.Context, groupA, groupB []Target) error {
+	if len(groupA) == 0 || len(groupB) == 0 {
+		return fmt.Errorf("both sides of a partition must contain at least one node")
+	}
+
+	ipsA, err := internalIPs(ctx, groupA)
+	if err != nil {
+		return err
+	}
+	ipsB, err := internalIPs(ctx, groupB)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range groupA {
+		if err := i.apply(ctx, Fault{Kind: FaultPartition, Target: t.Name(), ContainerID: t.ContainerID(), PeerIPs: ipsB}); err != nil {
+			return err
+		}
+	}
+	for _, t := range groupB {
+		if err := i.apply(ctx, Fault{Kind: FaultPartition, Target: t.Name(), ContainerID: t.ContainerID(), PeerIPs: ipsA}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Isolate drops all traffic between target and each of the given peers.
+func (i *Injector) Isolate(ctx context.Context, target Target, peers ...Target) error {
+	return i.Partition(ctx, []Target{target}, peers)
+}
+
+// SetLinkFault degrades the target's network interface according to the given LinkFault,
+// replacing any link fault previously applied to the same interface.
+func (i *Injector) SetLinkFault(ctx context.Context, target Target, link LinkFault) error {
+	if err := link.Validate(); err != nil {
+		return fmt.Errorf("invalid link fault: %w", err)
+	}
+
+	f := Fault{Kind: FaultLink, Target: target.Name(), ContainerID: target.ContainerID(), Link: link}
+	if err := i.exec(ctx, f.ContainerID, f.applyCmd()); err != nil {
+		return fmt.Errorf("failed to apply link fault to %s: %w", f.Target, err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = removeFaults(i.faults, func(existing Fault) bool {
+		return existing.Kind == FaultLink && existing.ContainerID == f.ContainerID && existing.Link.iface() == link.iface()
+	})
+	i.faults = append(i.faults, f)
+
+	i.log.Info("applied link fault", zap.String("target", f.Target), zap.Strings("cmd", f.applyCmd()))
+	return nil
+}
+
+// Heal reverts all faults applied to the given targets. If no targets are provided, every fault
+// applied by the Injector is healed.
+func (i *Injector) Heal(ctx context.Context, targets ...Target) error {
+	containerIDs := make(map[string]struct{}, len(targets))
+	for _, t := range targets {
+		containerIDs[t.ContainerID()] = struct{}{}
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var errs []error
+	var remaining []Fault
+	for _, f := range i.faults {
+		if _, ok := containerIDs[f.ContainerID]; len(targets) > 0 && !ok {
+			remaining = append(remaining, f)
+			continue
+		}
+		if err := i.heal(ctx, f); err != nil {
+			errs = append(errs, err)
+			remaining = append(remaining, f)
+		}
+	}
+	i.faults = remaining
+	return errors.Join(errs...)
+}
+
+// apply applies the fault and records it.
+func (i *Injector) apply(ctx context.Context, f Fault) error {
+	if err := i.exec(ctx, f.ContainerID, f.applyCmd()); err != nil {
+		return fmt.Errorf("failed to apply %s fault to %s: %w", f.Kind, f.Target, err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = append(i.faults, f)
+
+	i.log.Info("applied fault", zap.String("kind", string(f.Kind)), zap.String("target", f.Target), zap.Strings("peers", f.PeerIPs))
+	return nil
+}
+
+// heal reverts a single fault. Faults on containers which are no longer running are considered
+// healed, as the network namespace they were applied to no longer exists.
+func (i *Injector) heal(ctx context.Context, f Fault) error {
+	running, err := i.isRunning(ctx, f.ContainerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", f.Target, err)
+	}
+	if !running {
+		i.log.Info("dropping fault on stopped container", zap.String("kind", string(f.Kind)), zap.String("target", f.Target))
+		return nil
+	}
+
+	if err := i.exec(ctx, f.ContainerID, f.healCmd()); err != nil {
+		return fmt.Errorf("failed to heal %s fault on %s: %w", f.Kind, f.Target, err)
+	}
+
+	i.log.Info("healed fault", zap.String("kind", string(f.Kind)), zap.String("target", f.Target))
+	return nil
+}
+
+// isRunning reports whether the container with the given ID exists and is running.
+func (i *Injector) isRunning(ctx context.Context, containerID string) (bool, error) {
+	res, err := i.cfg.DockerClient.ContainerInspect(ctx, containerID, client.ContainerInspectOptions{})
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return res.Container.State.Running, nil
+}
+
+// exec runs cmd in a sidecar container that shares the network namespace of the given container.
+func (i *Injector) exec(ctx context.Context, containerID string, cmd []string) error {
+	job := container.NewJob(i.log, i.cfg.DockerClient, i.cfg.DockerNetworkID, i.testName, i.cfg.Image.Repository, i.cfg.Image.Version)
+	res := job.Run(ctx, cmd, container.Options{
+		User:        consts.UserRootString,
+		NetworkMode: "container:" + containerID,
+		CapAdd:      []string{"NET_ADMIN"},
+	})
+	return res.Err
+}
+
+// internalIPs returns the docker network address of each target.
+func internalIPs(ctx context.Context, targets []Target) ([]string, error) {
+	ips := make([]string, 0, len(targets))
+	for _, t := range targets {
+		info, err := t.GetNetworkInfo(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get network info for %s: %w", t.Name(), err)
+		}
+		if info.Internal.IP == "" {
+			return nil, fmt.Errorf("node %s has no internal IP, is it running?", t.Name())
+		}
+		ips = append(ips, info.Internal.IP)
+	}
+	return ips, nil
+}
+
+// removeFaults returns the faults for which remove returns false.
+func removeFaults(faults []Fault, remove func(Fault) bool) []Fault {
+	var kept []Fault
+	for _, f := range faults {
+		if !remove(f) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+var (
+	registryMu sync.Mutex
+	// registry maps a docker client cleanup label to the injectors created with that client.
+	registry = map[string][]*Injector{}
+)
+
+// register associates the injector with the given cleanup label.
+func register(cleanupLabel string, i *Injector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[cleanupLabel] = append(registry[cleanupLabel], i)
+}
+
+// HealAll heals every fault applied by injectors created with the given cleanup label and
+// forgets about those injectors. It is invoked by docker.CleanupWithLabel.
+func HealAll(ctx context.Context, cleanupLabel string) error {
+	registryMu.Lock()
+	injectors := registry[cleanupLabel]
+	delete(registry, cleanupLabel)
+	registryMu.Unlock()
+
+	var errs []error
+	for _, i := range injectors {
+		if err := i.Heal(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
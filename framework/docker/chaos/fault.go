@@ -0,0 +1,145 @@
+package chaos
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FaultKind identifies the type of network fault applied to a node.
+type FaultKind string
+
+const (
+	// FaultPartition drops all traffic between a node and a set of peers.
+	FaultPartition FaultKind = "partition"
+	// FaultLink degrades a node's network interface with latency, jitter and packet loss.
+	FaultLink FaultKind = "link"
+)
+
+// defaultInterface is the interface a container uses on the shared docker network.
+const defaultInterface = "eth0"
+
+// ruleComment tags every iptables rule added by the injector so that it can be identified.
+const ruleComment = "tastora-chaos"
+
+// LinkFault describes the impairment applied to a node's network interface using netem.
+type LinkFault struct {
+	// Latency is the delay added to every outgoing packet.
+	Latency time.Duration
+	// Jitter is the random variation applied to Latency. Ignored if Latency is zero.
+	Jitter time.Duration
+	// PacketLoss is the percentage (0-100) of outgoing packets that are dropped.
+	PacketLoss float64
+	// Interface is the network interface to impair. Defaults to eth0.
+	Interface string
+}
+
+// Validate returns an error if the LinkFault does not describe a valid impairment.
+func (l LinkFault) Validate() error {
+	if l.Latency < 0 || l.Jitter < 0 {
+		return fmt.Errorf("latency and jitter must not be negative")
+	}
+	if l.PacketLoss < 0 || l.PacketLoss > 100 {
+		return fmt.Errorf("packet loss must be between 0 and 100, got %v", l.PacketLoss)
+	}
+	if l.Latency == 0 && l.PacketLoss == 0 {
+		return fmt.Errorf("link fault must specify latency or packet loss")
+	}
+	return nil
+}
+
+// iface returns the interface the fault applies to.
+func (l LinkFault) iface() string {
+	if l.Interface == "" {
+		return defaultInterface
+	}
+	return l.Interface
+}
+
+// applyCmd returns the tc command which applies the fault, replacing any existing root qdisc.
+func (l LinkFault) applyCmd() []string {
+	cmd := []string{"tc", "qdisc", "replace", "dev", l.iface(), "root", "netem"}
+	if l.Latency > 0 {
+		cmd = append(cmd, "delay", formatMillis(l.Latency))
+		if l.Jitter > 0 {
+			cmd = append(cmd, formatMillis(l.Jitter))
+		}
+	}
+	if l.PacketLoss > 0 {
+		cmd = append(cmd, "loss", strconv.FormatFloat(l.PacketLoss, 'f', -1, 64)+"%")
+	}
+	return cmd
+}
+
+// healCmd returns the tc command which removes the fault.
+func (l LinkFault) healCmd() []string {
+	return []string{"tc", "qdisc", "del", "dev", l.iface(), "root"}
+}
+
+// formatMillis formats a duration in the millisecond unit understood by tc.
+func formatMillis(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64) + "ms"
+}
+
+// Fault is a record of a network fault applied to a single node.
+type Fault struct {
+	// Kind is the type of fault.
+	Kind FaultKind
+	// Target is the name of the node the fault was applied to.
+	Target string
+	// ContainerID is the ID of the container whose network namespace was modified.
+	ContainerID string
+	// PeerIPs are the addresses traffic is dropped to and from. Only set for FaultPartition.
+	PeerIPs []string
+	// Link is the impairment applied. Only set for FaultLink.
+	Link LinkFault
+}
+
+// applyCmd returns the shell script which applies the fault inside the target's network namespace.
+func (f Fault) applyCmd() []string {
+	switch f.Kind {
+	case FaultPartition:
+		return partitionScript("-A", f.PeerIPs)
+	case FaultLink:
+		return f.Link.applyCmd()
+	default:
+		return nil
+	}
+}
+
+// healCmd returns the shell script which reverts the fault inside the target's network namespace.
+func (f Fault) healCmd() []string {
+	switch f.Kind {
+	case FaultPartition:
+		return partitionScript("-D", f.PeerIPs)
+	case FaultLink:
+		return f.Link.healCmd()
+	default:
+		return nil
+	}
+}
+
+// partitionScript returns a command which adds (-A) or deletes (-D) iptables rules dropping
+// all inbound and outbound traffic for each of the given peer addresses. If adding a rule fails, the rules
+// already added are deleted again, as the fault is only recorded once all of them are applied.
+func partitionScript(action string, peerIPs []string) []string {
+	script := "set -e"
+	if action == "-A" {
+		script += "; added=''; trap 'eval \"$added\"' EXIT"
+	}
+	for _, ip := range peerIPs {
+		for _, rule := range []string{
+			fmt.Sprintf("INPUT -s %s -j DROP -m comment --comment %s", ip, ruleComment),
+			fmt.Sprintf("OUTPUT -d %s -j DROP -m comment --comment %s", ip, ruleComment),
+		} {
+			script += fmt.Sprintf("; iptables %s %s", action, rule)
+			if action == "-A" {
+				script += fmt.Sprintf("; added=\"iptables -D %s; $added\"", rule)
+			}
+		}
+	}
+	if action == "-A" {
+		script += "; trap - EXIT"
+	}
+	return []string{"sh", "-c", script}
+}
@@ -0,0 +1,108 @@
+package chaos
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkFaultApplyCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		fault    LinkFault
+		expected []string
+	}{
+		{
+			name:     "LatencyOnly",
+			fault:    LinkFault{Latency: 100 * time.Millisecond},
+			expected: []string{"tc", "qdisc", "replace", "dev", "eth0", "root", "netem", "delay", "100ms"},
+		},
+		{
+			name:     "LatencyAndJitter",
+			fault:    LinkFault{Latency: 250 * time.Millisecond, Jitter: 1500 * time.Microsecond},
+			expected: []string{"tc", "qdisc", "replace", "dev", "eth0", "root", "netem", "delay", "250ms", "1.5ms"},
+		},
+		{
+			name:     "LossOnCustomInterface",
+			fault:    LinkFault{PacketLoss: 12.5, Interface: "eth1"},
+			expected: []string{"tc", "qdisc", "replace", "dev", "eth1", "root", "netem", "loss", "12.5%"},
+		},
+		{
+			name:     "AllValues",
+			fault:    LinkFault{Latency: time.Second, Jitter: 10 * time.Millisecond, PacketLoss: 5},
+			expected: []string{"tc", "qdisc", "replace", "dev", "eth0", "root", "netem", "delay", "1000ms", "10ms", "loss", "5%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.fault.Validate())
+			require.Equal(t, tt.expected, tt.fault.applyCmd())
+		})
+	}
+}
+
+func TestLinkFaultValidate(t *testing.T) {
+	require.Error(t, LinkFault{}.Validate(), "empty fault should be invalid")
+	require.Error(t, LinkFault{Latency: -time.Millisecond}.Validate())
+	require.Error(t, LinkFault{PacketLoss: 101}.Validate())
+	require.NoError(t, LinkFault{PacketLoss: 100}.Validate())
+}
+
+func TestFaultCommands(t *testing.T) {
+	f := Fault{Kind: FaultPartition, PeerIPs: []string{"172.18.0.2", "172.18.0.3"}}
+
+	apply := f.applyCmd()
+	require.Equal(t, []string{"sh", "-c"}, apply[:2])
+	require.Contains(t, apply[2], "iptables -A INPUT -s 172.18.0.2 -j DROP")
+	require.Contains(t, apply[2], "iptables -A OUTPUT -d 172.18.0.3 -j DROP")
+
+	heal := f.healCmd()
+	require.Contains(t, heal[2], "iptables -D INPUT -s 172.18.0.2 -j DROP")
+	require.Contains(t, heal[2], "iptables -D OUTPUT -d 172.18.0.3 -j DROP")
+
+	link := Fault{Kind: FaultLink, Link: LinkFault{Latency: time.Millisecond}}
+	require.Equal(t, []string{"tc", "qdisc", "del", "dev", "eth0", "root"}, link.healCmd())
+}
+
+// TestPartitionScriptRollback runs the partition script against a fake iptables to verify that the rules
+// already added are deleted again when adding a rule fails.
+func TestPartitionScriptRollback(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+	// the fake iptables logs the rules it applies and fails to add rules for 172.18.0.3.
+	fake := "#!/bin/sh\ncase \"$*\" in -A*172.18.0.3*) exit 1;; esac\necho \"$*\" >> " + log + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "iptables"), []byte(fake), 0o755))
+
+	run := func(peerIPs ...string) ([]string, error) {
+		require.NoError(t, os.RemoveAll(log))
+		cmd := partitionScript("-A", peerIPs)
+		c := exec.Command(cmd[0], cmd[1:]...)
+		c.Env = append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+		runErr := c.Run()
+		bz, err := os.ReadFile(log)
+		require.NoError(t, err)
+		return strings.Split(strings.TrimSpace(string(bz)), "\n"), runErr
+	}
+
+	applied, err := run("172.18.0.2")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-A INPUT -s 172.18.0.2 -j DROP -m comment --comment tastora-chaos",
+		"-A OUTPUT -d 172.18.0.2 -j DROP -m comment --comment tastora-chaos",
+	}, applied)
+
+	applied, err = run("172.18.0.2", "172.18.0.3")
+	require.Error(t, err)
+	require.Equal(t, []string{
+		"-A INPUT -s 172.18.0.2 -j DROP -m comment --comment tastora-chaos",
+		"-A OUTPUT -d 172.18.0.2 -j DROP -m comment --comment tastora-chaos",
+		"-D OUTPUT -d 172.18.0.2 -j DROP -m comment --comment tastora-chaos",
+		"-D INPUT -s 172.18.0.2 -j DROP -m comment --comment tastora-chaos",
+	}, applied, "the rules added before the failure should be deleted")
+}
@@ -0,0 +1,161 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/docker/chaos"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	da "github.com/celestiaorg/tastora/framework/docker/dataavailability"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNetworkPartition verifies that partitioning validators halts the chain and that healing
+// the partition allows block production to resume.
+func TestNetworkPartition(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.
+		WithNodes(
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().Build(),
+		).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	targets, err := chaos.TargetsFromChainNodes(chain.GetNodes())
+	require.NoError(t, err)
+
+	injector := chaos.NewInjector(chaos.Config{
+		Logger:          testCfg.Logger,
+		DockerClient:    testCfg.DockerClient,
+		DockerNetworkID: testCfg.NetworkID,
+	}, testCfg.TestName)
+
+	t.Run("partition halts the chain", func(t *testing.T) {
+		require.NoError(t, injector.Partition(testCfg.Ctx, targets[:1], targets[1:]))
+		require.Len(t, injector.Faults(), 2, "a fault should be recorded for each side of the partition")
+
+		// neither side has more than 2/3 of the voting power, so no new blocks can be committed.
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, 20*time.Second)
+		defer cancel()
+		require.Error(t, wait.ForBlocks(ctx, 3, chain), "chain should not produce blocks while partitioned")
+	})
+
+	t.Run("heal resumes block production", func(t *testing.T) {
+		require.NoError(t, injector.Heal(testCfg.Ctx))
+		require.Empty(t, injector.Faults())
+
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, 2*time.Minute)
+		defer cancel()
+		require.NoError(t, wait.ForBlocks(ctx, 3, chain))
+	})
+
+	t.Run("link fault", func(t *testing.T) {
+		err := injector.SetLinkFault(testCfg.Ctx, targets[0], chaos.LinkFault{
+			Latency:    200 * time.Millisecond,
+			Jitter:     50 * time.Millisecond,
+			PacketLoss: 5,
+		})
+		require.NoError(t, err)
+		require.Len(t, injector.Faults(), 1)
+
+		// the chain keeps making progress under a degraded link.
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, 2*time.Minute)
+		defer cancel()
+		require.NoError(t, wait.ForBlocks(ctx, 2, chain))
+
+		// the fault is intentionally left in place to be healed by the Setup cleanup.
+	})
+}
+
+// TestDANetworkPartition verifies that partitioning a bridge node from the chain stops it from syncing headers
+// and that healing the partition allows it to catch up.
+func TestDANetworkPartition(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	daNetwork, err := testCfg.DANetworkBuilder.
+		WithChainID(chain.GetChainID()).
+		WithNodes(da.NewNodeBuilder().WithNodeType(types.BridgeNode).Build()).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+
+	bridgeNode := daNetwork.GetBridgeNodes()[0]
+
+	chainNetworkInfo, err := chain.GetNodes()[0].GetNetworkInfo(testCfg.Ctx)
+	require.NoError(t, err)
+	chainID := chain.GetChainID()
+	genesisHash, err := getGenesisHash(testCfg.Ctx, chain)
+	require.NoError(t, err)
+
+	require.NoError(t, bridgeNode.Start(testCfg.Ctx,
+		da.WithChainID(chainID),
+		da.WithAdditionalStartArguments("--p2p.network", chainID, "--core.ip", chainNetworkInfo.Internal.Hostname, "--rpc.addr", "0.0.0.0"),
+		da.WithEnvironmentVariables(map[string]string{
+			"CELESTIA_CUSTOM": types.BuildCelestiaCustomEnvVar(chainID, genesisHash, ""),
+			"P2P_NETWORK":     chainID,
+		}),
+	))
+
+	chainTargets, err := chaos.TargetsFromChainNodes(chain.GetNodes())
+	require.NoError(t, err)
+	daTargets := chaos.TargetsFromDANodes(daNetwork.GetBridgeNodes())
+	require.Len(t, daTargets, 1)
+
+	injector := chaos.NewInjector(chaos.Config{
+		Logger:          testCfg.Logger,
+		DockerClient:    testCfg.DockerClient,
+		DockerNetworkID: testCfg.NetworkID,
+	}, testCfg.TestName)
+
+	ctx, cancel := context.WithTimeout(testCfg.Ctx, 2*time.Minute)
+	defer cancel()
+	_, err = wait.ForNewDAHeaders(ctx, bridgeNode, 2)
+	require.NoError(t, err, "bridge node should sync headers before the partition")
+
+	var partitionedHeight uint64
+	t.Run("partition stops header sync", func(t *testing.T) {
+		require.NoError(t, injector.Partition(testCfg.Ctx, chainTargets, daTargets))
+
+		head, err := bridgeNode.GetLocalHead(testCfg.Ctx)
+		require.NoError(t, err)
+
+		// the chain keeps producing blocks, but the bridge node can no longer fetch them.
+		require.NoError(t, wait.ForBlocks(testCfg.Ctx, 5, chain))
+
+		partitioned, err := bridgeNode.GetLocalHead(testCfg.Ctx)
+		require.NoError(t, err)
+		// a header which was in flight when the partition was applied may still be stored.
+		require.LessOrEqual(t, partitioned.Height, head.Height+1, "bridge node should not sync headers while partitioned")
+		partitionedHeight = partitioned.Height
+	})
+
+	t.Run("heal resumes header sync", func(t *testing.T) {
+		require.NoError(t, injector.Heal(testCfg.Ctx))
+		require.Empty(t, injector.Faults())
+
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, 2*time.Minute)
+		defer cancel()
+		require.NoError(t, wait.ForDAHeight(ctx, bridgeNode, partitionedHeight+3))
+	})
+}
@@ -94,6 +94,13 @@ type Options struct {
 
 	// working directory to launch cmd from
 	WorkingDir string
+
+	// NetworkMode overrides the network the container joins, e.g. "container:<id>" to share the
+	// network namespace of another container. If blank, the job's network is used.
+	NetworkMode string
+
+	// CapAdd lists additional kernel capabilities granted to the container, e.g. NET_ADMIN.
+	CapAdd []string
 }
 
 // ExecResult is a wrapper type that wraps an exit code and associated output from stderr & stdout, along with
@@ -163,6 +170,28 @@ func (job *Job) CreateContainer(ctx context.Context, containerName, hostName str
 		}
 	}
 
+	hostConfig := &container.HostConfig{
+		Binds:           opts.Binds,
+		PublishAllPorts: true, // Because we publish all ports, no need to expose specific ports.
+		AutoRemove:      false,
+		Mounts:          opts.Mounts,
+		CapAdd:          opts.CapAdd,
+	}
+	networkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			job.networkID: {},
+		},
+	}
+
+	// a container sharing another container's network namespace can neither publish ports,
+	// set its own hostname nor join additional networks.
+	if opts.NetworkMode != "" {
+		hostConfig.NetworkMode = container.NetworkMode(opts.NetworkMode)
+		hostConfig.PublishAllPorts = false
+		networkingConfig = nil
+		hostName = ""
+	}
+
 	cc, err := job.client.ContainerCreate(
 		ctx,
 		client.ContainerCreateOptions{
@@ -181,17 +210,8 @@ func (job *Job) CreateContainer(ctx context.Context, containerName, hostName str
 
 				Labels: map[string]string{consts.CleanupLabel: job.client.CleanupLabel()},
 			},
-			HostConfig: &container.HostConfig{
-				Binds:           opts.Binds,
-				PublishAllPorts: true, // Because we publish all ports, no need to expose specific ports.
-				AutoRemove:      false,
-				Mounts:          opts.Mounts,
-			},
-			NetworkingConfig: &network.NetworkingConfig{
-				EndpointsConfig: map[string]*network.EndpointSettings{
-					job.networkID: {},
-				},
-			},
+			HostConfig:       hostConfig,
+			NetworkingConfig: networkingConfig,
 		},
 	)
 	if err != nil {
@@ -58,6 +58,11 @@ func (n *Node) SetContainerLifecycle(lifecycle *Lifecycle) {
 	n.ContainerLifecycle = lifecycle
 }
 
+// ContainerID returns the ID of the docker container backing the node.
+func (n *Node) ContainerID() string {
+	return n.ContainerLifecycle.ContainerID()
+}
+
 // HomeDir returns the home directory path
 func (n *Node) HomeDir() string {
 	return n.homeDir
@@ -86,6 +86,11 @@ func (b *broadcaster) ConfigureClientContextOptions(opts ...types.ClientContextO
 // and the provided wallet. ConfigureFactoryOptions can be used to specify arbitrary options to configure the returned
 // factory.
 func (b *broadcaster) GetFactory(ctx context.Context, wallet *types.Wallet) (sdktx.Factory, error) {
+	return b.getFactory(ctx, wallet, nil)
+}
+
+// getFactory returns the factory of GetFactory, with a gas limit covering the blobs the transaction pays for.
+func (b *broadcaster) getFactory(ctx context.Context, wallet *types.Wallet, blobs []*share.Blob) (sdktx.Factory, error) {
 	clientContext, err := b.GetClientContext(ctx, wallet)
 	if err != nil {
 		return sdktx.Factory{}, err
@@ -101,7 +106,14 @@ func (b *broadcaster) GetFactory(ctx context.Context, wallet *types.Wallet) (sdk
 		return sdktx.Factory{}, err
 	}
 
-	f := b.defaultTxFactory(clientContext, account)
+	f, err := b.defaultTxFactory(clientContext, account)
+	if err != nil {
+		return sdktx.Factory{}, err
+	}
+	f, err = b.getNode().configureGas(ctx, b.chain.Config, f, blobs)
+	if err != nil {
+		return sdktx.Factory{}, err
+	}
 	for _, opt := range b.factoryOptions {
 		f = opt(f)
 	}
@@ -187,21 +199,36 @@ func (b *broadcaster) defaultClientContext(fromWallet *types.Wallet, sdkAdd sdk.
 }
 
 // defaultTxFactory creates a new Factory with default configuration.
-func (b *broadcaster) defaultTxFactory(clientCtx client.Context, account client.Account) sdktx.Factory {
-	chainConfig := b.chain.Config
+func (b *broadcaster) defaultTxFactory(clientCtx client.Context, account client.Account) (sdktx.Factory, error) {
+	return newTxFactory(b.chain.Config, clientCtx, account.GetAccountNumber(), account.GetSequence())
+}
+
+// newTxFactory creates a new Factory with the default configuration of the chain, signing with the given
+// account number and sequence. When the chain simulates transactions, the default gas limit is kept for
+// transactions which are built without being simulated.
+func newTxFactory(chainConfig ChainConfig, clientCtx client.Context, accountNumber, sequence uint64) (sdktx.Factory, error) {
+	gasSetting, err := flags.ParseGasSetting(chainConfig.Gas)
+	if err != nil {
+		return sdktx.Factory{}, fmt.Errorf("invalid gas %q: %w", chainConfig.Gas, err)
+	}
+	gas := gasSetting.Gas
+	if gasSetting.Simulate {
+		gas = flags.DefaultGasLimit
+	}
 	return sdktx.Factory{}.
-		WithAccountNumber(account.GetAccountNumber()).
-		WithSequence(account.GetSequence()).
+		WithAccountNumber(accountNumber).
+		WithSequence(sequence).
 		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
 		WithGasAdjustment(chainConfig.GasAdjustment).
-		WithGas(flags.DefaultGasLimit).
+		WithGas(gas).
 		WithGasPrices(chainConfig.GasPrices).
 		WithMemo("celestia-test").
 		WithTxConfig(clientCtx.TxConfig).
 		WithAccountRetriever(clientCtx.AccountRetriever).
 		WithKeybase(clientCtx.Keyring).
 		WithChainID(clientCtx.ChainID).
-		WithSimulateAndExecute(false)
+		WithFromName(clientCtx.FromName).
+		WithSimulateAndExecute(gasSetting.Simulate), nil
 }
 
 // BroadcastBlobMessage uses the provided Broadcaster to broadcast all the provided message which will be signed
@@ -213,7 +240,7 @@ func (b *broadcaster) BroadcastBlobMessage(ctx context.Context, signingWallet *t
 		return sdk.TxResponse{}, err
 	}
 
-	txf, err := b.GetFactory(ctx, signingWallet)
+	txf, err := b.getFactory(ctx, signingWallet, blobs)
 	if err != nil {
 		return sdk.TxResponse{}, err
 	}
@@ -223,6 +250,11 @@ func (b *broadcaster) BroadcastBlobMessage(ctx context.Context, signingWallet *t
 		return sdk.TxResponse{}, err
 	}
 
+	txf, err = simulateGas(cc, txf, msg)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
 	txBuilder, err := txf.BuildUnsignedTx(msg)
 	if err != nil {
 		return sdk.TxResponse{}, err
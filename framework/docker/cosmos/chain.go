@@ -9,15 +9,16 @@ import (
 	"path"
 	"strconv"
 	"sync"
-	"testing"
 	"time"
 
+	"github.com/celestiaorg/tastora/framework/testutil/events"
 	"github.com/celestiaorg/tastora/framework/testutil/maps"
 	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 
 	sdkmath "cosmossdk.io/math"
 	"github.com/celestiaorg/go-square/v3/share"
 	"github.com/celestiaorg/tastora/framework/docker/consts"
+	"github.com/celestiaorg/tastora/framework/docker/container"
 	addressutil "github.com/celestiaorg/tastora/framework/testutil/address"
 	"github.com/celestiaorg/tastora/framework/testutil/wait"
 	"github.com/celestiaorg/tastora/framework/types"
@@ -32,7 +33,7 @@ import (
 var _ types.Chain = &Chain{}
 
 type Chain struct {
-	t          *testing.T
+	t          types.TestingT
 	Config     ChainConfig
 	Validators ChainNodes
 	FullNodes  ChainNodes
@@ -50,6 +51,9 @@ type Chain struct {
 	started bool
 	// skipInit indicates whether to skip initialization when starting
 	skipInit bool
+	// existingVolumes indicates that the node volumes already hold the files of a chain, which is started
+	// from them instead of being initialized.
+	existingVolumes bool
 	// blockWaitTimeout is the timeout for waiting for blocks after starting the chain.
 	// If zero, defaults to 120 seconds.
 	blockWaitTimeout time.Duration
@@ -57,12 +61,13 @@ type Chain struct {
 
 func (c *Chain) GetRelayerConfig() types.ChainRelayerConfig {
 	return types.ChainRelayerConfig{
-		ChainID:      c.GetChainID(),
-		Denom:        c.Config.Denom,
-		GasPrices:    c.Config.GasPrices,
-		Bech32Prefix: c.Config.Bech32Prefix,
-		RPCAddress:   "http://" + c.GetNode().Name() + ":26657",
-		GRPCAddress:  "http://" + c.GetNode().Name() + ":9090",
+		ChainID:        c.GetChainID(),
+		Denom:          c.Config.Denom,
+		GasPrices:      c.Config.GasPrices,
+		Bech32Prefix:   c.Config.Bech32Prefix,
+		RPCAddress:     "http://" + c.GetNode().Name() + ":26657",
+		GRPCAddress:    "http://" + c.GetNode().Name() + ":9090",
+		TrustingPeriod: c.Config.TrustingPeriod,
 	}
 }
 
@@ -176,6 +181,13 @@ func (c *Chain) AddNode(ctx context.Context, nodeConfig ChainNodeConfig) error {
 		return err
 	}
 
+	// test case has explicitly set a priv_validator_key.json contents.
+	if node.PrivValidatorKey != nil {
+		if err := node.overwritePrivValidatorKey(ctx, node.PrivValidatorKey); err != nil {
+			return err
+		}
+	}
+
 	// execute any custom post-init functions
 	// these can modify config files or modify genesis etc.
 	for _, fn := range node.PostInit {
@@ -230,9 +242,54 @@ func (c *Chain) Start(ctx context.Context) error {
 	if c.started || c.skipInit {
 		return c.startAllNodes(ctx)
 	}
+	if c.existingVolumes {
+		return c.startFromExistingVolumes(ctx)
+	}
 	return c.startAndInitializeNodes(ctx)
 }
 
+// startFromExistingVolumes starts all chain nodes using the files already present in their volumes,
+// e.g. volumes reused from a previous chain or restored from a snapshot.
+// The persistent peers are rewritten as the hostnames of the nodes may differ from when the volumes were created.
+func (c *Chain) startFromExistingVolumes(ctx context.Context) error {
+	c.started = true
+	chainNodes := c.Nodes()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		n := n
+		eg.Go(func() error {
+			return n.createNodeContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	peers, err := addressutil.BuildInternalPeerAddressList(ctx, chainNodes)
+	if err != nil {
+		return err
+	}
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		n := n
+		eg.Go(func() error {
+			if err := n.setPeers(egCtx, peers); err != nil {
+				return err
+			}
+			return n.startContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	blockWaitCtx, cancel := context.WithTimeout(ctx, c.getBlockWaitTimeout())
+	defer cancel()
+	return wait.ForBlocks(blockWaitCtx, 2, c.GetNode())
+}
+
 // startAndInitializeNodes initializes and starts all chain nodes, configures genesis files, and ensures proper setup for the chain.
 func (c *Chain) startAndInitializeNodes(ctx context.Context) error {
 	c.started = true
@@ -249,6 +306,13 @@ func (c *Chain) startAndInitializeNodes(ctx context.Context) error {
 				return err
 			}
 
+			// test case has explicitly set a priv_validator_key.json contents, which the gentx has to be signed with.
+			if v.PrivValidatorKey != nil {
+				if err := v.overwritePrivValidatorKey(ctx, v.PrivValidatorKey); err != nil {
+					return err
+				}
+			}
+
 			// we don't want to initialize the validator if it has a keyring.
 			if v.GenesisKeyring != nil {
 				return nil
@@ -262,7 +326,15 @@ func (c *Chain) startAndInitializeNodes(ctx context.Context) error {
 		n := n
 		n.Validator = false
 		eg.Go(func() error {
-			return n.initNodeFiles(ctx)
+			if err := n.initNodeFiles(ctx); err != nil {
+				return err
+			}
+
+			// test case has explicitly set a priv_validator_key.json contents.
+			if n.PrivValidatorKey != nil {
+				return n.overwritePrivValidatorKey(ctx, n.PrivValidatorKey)
+			}
+			return nil
 		})
 	}
 
@@ -276,19 +348,19 @@ func (c *Chain) startAndInitializeNodes(ctx context.Context) error {
 		return fmt.Errorf("failed to get genesis file: %w", err)
 	}
 
+	if len(c.Config.GenesisModifications) > 0 {
+		genesisBz, err = maps.SetFields(genesisBz, c.Config.GenesisModifications...)
+		if err != nil {
+			return fmt.Errorf("failed to apply genesis modifications: %w", err)
+		}
+	}
+
 	chainNodes := c.Nodes()
 	for _, cn := range chainNodes {
 
 		if err := cn.overwriteGenesisFile(ctx, genesisBz); err != nil {
 			return err
 		}
-
-		// test case has explicitly set a priv_validator_key.json contents.
-		if cn.PrivValidatorKey != nil {
-			if err := cn.overwritePrivValidatorKey(ctx, cn.PrivValidatorKey); err != nil {
-				return err
-			}
-		}
 	}
 
 	// for all chain nodes, execute any functions provided.
@@ -334,11 +406,7 @@ func (c *Chain) startAndInitializeNodes(ctx context.Context) error {
 	}
 
 	// Wait for blocks before considering the chains "started"
-	blockWaitTimeout := c.blockWaitTimeout
-	if blockWaitTimeout == 0 {
-		blockWaitTimeout = 120 * time.Second
-	}
-	blockWaitCtx, cancel := context.WithTimeout(ctx, blockWaitTimeout)
+	blockWaitCtx, cancel := context.WithTimeout(ctx, c.getBlockWaitTimeout())
 	defer cancel()
 	if err := wait.ForBlocks(blockWaitCtx, 2, c.GetNode()); err != nil {
 		return err
@@ -432,6 +500,14 @@ func (c *Chain) initDefaultGenesis(ctx context.Context, defaultGenesisAmount sdk
 	return genbz, nil
 }
 
+// getBlockWaitTimeout returns the timeout for waiting for blocks, defaulting to 120 seconds.
+func (c *Chain) getBlockWaitTimeout() time.Duration {
+	if c.blockWaitTimeout == 0 {
+		return 120 * time.Second
+	}
+	return c.blockWaitTimeout
+}
+
 func (c *Chain) GetNode() *ChainNode {
 	return c.Nodes()[0]
 }
@@ -472,6 +548,31 @@ func (c *Chain) Stop(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// Pause pauses the containers of all nodes in the chain, which stops producing blocks until it is resumed, e.g. to
+// let packets sent to the chain time out. Nodes of a paused chain do not respond to queries.
+func (c *Chain) Pause(ctx context.Context) error {
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			return n.ContainerLifecycle.PauseContainer(ctx)
+		})
+	}
+	return eg.Wait()
+}
+
+// Resume unpauses the containers of all nodes in the chain paused by Pause.
+func (c *Chain) Resume(ctx context.Context) error {
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		n := n
+		eg.Go(func() error {
+			return n.ContainerLifecycle.UnpauseContainer(ctx)
+		})
+	}
+	return eg.Wait()
+}
+
 // Remove stops and removes all nodes in the chain.
 func (c *Chain) Remove(ctx context.Context, opts ...types.RemoveOption) error {
 	var eg errgroup.Group
@@ -511,6 +612,94 @@ func (c *Chain) UpgradeVersion(ctx context.Context, version string) error {
 	return nil
 }
 
+// UpgradeNodes performs a rolling upgrade of the given nodes to the specified version.
+// Nodes are upgraded one at a time: each node's container is removed while preserving its volumes,
+// recreated with the new image version and restarted, and the upgrade only moves on to the next node
+// once the upgraded node has caught up with the rest of the chain.
+// Nodes which are not provided keep running their current version, allowing mixed-version validator sets to be tested.
+func (c *Chain) UpgradeNodes(ctx context.Context, version string, nodes ...*ChainNode) error {
+	if len(nodes) == 0 {
+		return fmt.Errorf("at least one node must be specified for upgrade")
+	}
+
+	for _, n := range nodes {
+		if !c.hasNode(n) {
+			return fmt.Errorf("node %s is not part of chain %s", n.Name(), c.GetChainID())
+		}
+	}
+
+	for _, n := range nodes {
+		if err := c.upgradeNode(ctx, version, n); err != nil {
+			return fmt.Errorf("failed to upgrade node %s: %w", n.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeNode restarts a single node with the specified version and waits for it to rejoin the chain.
+func (c *Chain) upgradeNode(ctx context.Context, version string, n *ChainNode) error {
+	if err := n.Remove(ctx, types.WithPreserveVolumes()); err != nil {
+		return fmt.Errorf("failed to remove container for upgrade: %w", err)
+	}
+
+	n.Image.Version = version
+	c.pullImage(ctx, n.Image)
+
+	if err := c.restartNode(ctx, n); err != nil {
+		return err
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, c.getBlockWaitTimeout())
+	defer cancel()
+
+	reference := c.referenceNode(n)
+	if reference == nil {
+		// the node is the only member of the chain, so it is in sync as soon as it produces blocks.
+		return wait.ForBlocks(syncCtx, 2, n)
+	}
+
+	if err := wait.ForInSync(syncCtx, reference, n); err != nil {
+		return fmt.Errorf("node did not sync with %s after upgrade: %w", reference.Name(), err)
+	}
+
+	c.log.Info("node upgraded", zap.String("node", n.Name()), zap.String("version", version))
+	return nil
+}
+
+// restartNode creates and starts a new container for a node which has previously been started.
+func (c *Chain) restartNode(ctx context.Context, n *ChainNode) error {
+	// prevent client calls during this time
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := n.createNodeContainer(ctx); err != nil {
+		return err
+	}
+	return n.startContainer(ctx)
+}
+
+// hasNode returns true if the node belongs to the chain.
+func (c *Chain) hasNode(node *ChainNode) bool {
+	for _, n := range c.Nodes() {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceNode returns a node other than the excluded one, preferring validators, or nil if there is none.
+func (c *Chain) referenceNode(excluded *ChainNode) *ChainNode {
+	for _, nodes := range []ChainNodes{c.Validators, c.FullNodes} {
+		for _, n := range nodes {
+			if n != excluded {
+				return n
+			}
+		}
+	}
+	return nil
+}
+
 // pullImages pulls all images used by the chain chains.
 func (c *Chain) pullImages(ctx context.Context) {
 	pulled := make(map[string]struct{})
@@ -521,22 +710,27 @@ func (c *Chain) pullImages(ctx context.Context) {
 		}
 
 		pulled[image.Ref()] = struct{}{}
-		rc, err := c.Config.DockerClient.ImagePull(
-			ctx,
-			image.Ref(),
-			client.ImagePullOptions{},
+		c.pullImage(ctx, image)
+	}
+}
+
+// pullImage pulls a single image, logging any failure.
+func (c *Chain) pullImage(ctx context.Context, image container.Image) {
+	rc, err := c.Config.DockerClient.ImagePull(
+		ctx,
+		image.Ref(),
+		client.ImagePullOptions{},
+	)
+	if err != nil {
+		c.log.Error("Failed to pull image",
+			zap.Error(err),
+			zap.String("repository", image.Repository),
+			zap.String("tag", image.Version),
 		)
-		if err != nil {
-			c.log.Error("Failed to pull image",
-				zap.Error(err),
-				zap.String("repository", image.Repository),
-				zap.String("tag", image.Version),
-			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
-		}
+		return
 	}
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
 }
 
 // CreateWallet creates a new wallet on the first node and copies the key to all other nodes.
@@ -725,18 +919,13 @@ func (c *Chain) SubmitAndVoteOnGovV1Proposal(ctx context.Context, proposal *govv
 
 // extractProposalIDFromResponse extracts the proposal ID from the transaction response events.
 func extractProposalIDFromResponse(resp sdk.TxResponse) (uint64, error) {
-	for _, event := range resp.Events {
-		if event.Type == "submit_proposal" {
-			for _, attr := range event.Attributes {
-				if attr.Key == "proposal_id" {
-					proposalID, err := strconv.ParseUint(attr.Value, 10, 64)
-					if err != nil {
-						return 0, fmt.Errorf("failed to parse proposal ID %q: %w", attr.Value, err)
-					}
-					return proposalID, nil
-				}
-			}
-		}
+	value, err := events.AttributeValue(resp.Events, "submit_proposal", "proposal_id")
+	if err != nil {
+		return 0, err
+	}
+	proposalID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse proposal ID %q: %w", value, err)
 	}
-	return 0, fmt.Errorf("proposal_id not found in transaction events")
+	return proposalID, nil
 }
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path"
-	"testing"
 	"time"
 
+	"github.com/celestiaorg/tastora/framework/docker/artifacts"
+	"github.com/celestiaorg/tastora/framework/docker/consts"
 	"github.com/celestiaorg/tastora/framework/docker/container"
+	"github.com/celestiaorg/tastora/framework/testutil/maps"
 	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
@@ -109,7 +112,7 @@ func (b *ChainNodeConfigBuilder) Build() ChainNodeConfig {
 // ChainBuilder defines a builder for configuring and initializing a blockchain for testing purposes.
 type ChainBuilder struct {
 	// t is the testing context used for test assertions, container naming, and test lifecycle management
-	t *testing.T
+	t types.TestingT
 	// testName is the unique test identifier used for Docker resource naming in parallel execution
 	testName string
 	// nodes is the array of node configurations that define the chain topology and individual node settings
@@ -120,6 +123,10 @@ type ChainBuilder struct {
 	dockerNetworkID string
 	// genesisBz contains raw bytes that should be written as the config/genesis.json file for the chain (optional)
 	genesisBz []byte
+	// exportedGenesisBz contains a genesis exported from another chain with Chain.ExportState (optional)
+	exportedGenesisBz []byte
+	// genesisModifications are field updates applied to the genesis of the chain before it is started (optional)
+	genesisModifications []maps.Entry
 	// encodingConfig is the Cosmos SDK encoding configuration for protobuf and amino serialization/deserialization
 	encodingConfig *testutil.TestEncodingConfig
 	// binaryName is the name of the blockchain binary executable. Default: "celestia-appd"
@@ -130,6 +137,12 @@ type ChainBuilder struct {
 	gasPrices string
 	// gasAdjustment is the multiplier for gas estimation to prevent out-of-gas errors. Default: 1.3 (30% buffer)
 	gasAdjustment float64
+	// gas is the gas limit of transactions, empty for the default limit or "auto" to simulate transactions (optional)
+	gas string
+	// gasPriceSource determines the gas price transactions are signed with. Default: the configured gasPrices
+	gasPriceSource GasPriceSource
+	// trustingPeriod is the trusting period of the light clients of the chain created by relayers (optional)
+	trustingPeriod string
 	// bech32Prefix is the address prefix for the blockchain. Default: "celestia"
 	bech32Prefix string
 	// denom is the native token denomination used in transactions and fees. Default: "utia"
@@ -155,6 +168,8 @@ type ChainBuilder struct {
 	additionalExposedPorts []string
 	// skipInit indicates whether to skip node initialization on start (useful when reusing volumes)
 	skipInit bool
+	// existingVolumes indicates whether to start the nodes from the files already present in their volumes
+	existingVolumes bool
 	// blockWaitTimeout is the timeout for waiting for blocks after starting the chain.
 	// If zero, defaults to 120 seconds.
 	blockWaitTimeout time.Duration
@@ -163,11 +178,11 @@ type ChainBuilder struct {
 }
 
 // NewChainBuilder initializes and returns a new ChainBuilder with default values for testing purposes.
-func NewChainBuilder(t *testing.T) *ChainBuilder {
+func NewChainBuilder(t types.TestingT) *ChainBuilder {
 	return NewChainBuilderWithTestName(t, t.Name())
 }
 
-func NewChainBuilderWithTestName(t *testing.T, testName string) *ChainBuilder {
+func NewChainBuilderWithTestName(t types.TestingT, testName string) *ChainBuilder {
 	t.Helper()
 	cb := &ChainBuilder{}
 	return cb.
@@ -196,9 +211,13 @@ func NewChainBuilderFromChain(chain *Chain) *ChainBuilder {
 		WithCoinType(cfg.CoinType).
 		WithGasPrices(cfg.GasPrices).
 		WithGasAdjustment(cfg.GasAdjustment).
+		WithGas(cfg.Gas).
+		WithGasPriceSource(cfg.GasPriceSource).
+		WithTrustingPeriod(cfg.TrustingPeriod).
 		WithBech32Prefix(cfg.Bech32Prefix).
 		WithDenom(cfg.Denom).
 		WithGenesis(cfg.GenesisFileBz).
+		WithGenesisModifications(cfg.GenesisModifications...).
 		WithImage(cfg.Image).
 		WithDockerClient(chain.Config.DockerClient).
 		WithDockerNetworkID(chain.Config.DockerNetworkID).
@@ -222,7 +241,7 @@ func (b *ChainBuilder) WithChainID(chainID string) *ChainBuilder {
 	return b
 }
 
-func (b *ChainBuilder) WithT(t *testing.T) *ChainBuilder {
+func (b *ChainBuilder) WithT(t types.TestingT) *ChainBuilder {
 	t.Helper()
 	b.t = t
 	return b
@@ -269,6 +288,22 @@ func (b *ChainBuilder) WithGenesis(genesisBz []byte) *ChainBuilder {
 	return b
 }
 
+// WithExportedGenesis starts the chain from a genesis exported from another chain with Chain.ExportState.
+// The chain ID and genesis time of the export are replaced when the chain is built, the rest of the state is kept.
+// As the exported validator set is reused, validators should be configured with the nodes returned by
+// Chain.ExportNodeConfigs of the exporting chain.
+func (b *ChainBuilder) WithExportedGenesis(exportedGenesisBz []byte) *ChainBuilder {
+	b.exportedGenesisBz = exportedGenesisBz
+	return b
+}
+
+// WithGenesisModifications sets fields of the genesis, given as dot separated paths, before the chain is started.
+// The modifications apply to the default genesis as well as to one set with WithGenesis or WithExportedGenesis.
+func (b *ChainBuilder) WithGenesisModifications(entries ...maps.Entry) *ChainBuilder {
+	b.genesisModifications = entries
+	return b
+}
+
 // WithEncodingConfig sets the encoding configuration
 func (b *ChainBuilder) WithEncodingConfig(config *testutil.TestEncodingConfig) *ChainBuilder {
 	b.encodingConfig = config
@@ -299,6 +334,27 @@ func (b *ChainBuilder) WithGasAdjustment(gasAdjustment float64) *ChainBuilder {
 	return b
 }
 
+// WithGas sets the gas limit of transactions: empty for the default limit, "auto" to use the gas used by
+// simulating the transaction multiplied by the gas adjustment, or a number.
+func (b *ChainBuilder) WithGas(gas string) *ChainBuilder {
+	b.gas = gas
+	return b
+}
+
+// WithGasPriceSource sets where the gas price transactions are signed with comes from, e.g. the minimum gas
+// price of the node or celestia-app's gas price estimation.
+func (b *ChainBuilder) WithGasPriceSource(source GasPriceSource) *ChainBuilder {
+	b.gasPriceSource = source
+	return b
+}
+
+// WithTrustingPeriod sets the trusting period of the light clients of the chain created by relayers, e.g. "2m".
+// A short trusting period lets clients of the chain expire within a test.
+func (b *ChainBuilder) WithTrustingPeriod(trustingPeriod string) *ChainBuilder {
+	b.trustingPeriod = trustingPeriod
+	return b
+}
+
 // WithBech32Prefix sets the bech32 prefix
 func (b *ChainBuilder) WithBech32Prefix(bech32Prefix string) *ChainBuilder {
 	b.bech32Prefix = bech32Prefix
@@ -353,6 +409,14 @@ func (b *ChainBuilder) WithSkipInit(skip bool) *ChainBuilder {
 	return b
 }
 
+// WithExistingVolumes sets whether to start the chain from the files already present in the node volumes, e.g. volumes
+// restored from a snapshot. Unlike WithSkipInit, the persistent peers are rewritten and the chain is waited on to
+// produce blocks.
+func (b *ChainBuilder) WithExistingVolumes(existing bool) *ChainBuilder {
+	b.existingVolumes = existing
+	return b
+}
+
 // WithBlockWaitTimeout sets the timeout for waiting for blocks after starting
 // the chain. If not set, defaults to 120 seconds. Use a longer timeout for
 // chains that need extra time to start (e.g. state sync nodes).
@@ -410,10 +474,23 @@ func (b *ChainBuilder) getEnv(nodeConfig ChainNodeConfig) []string {
 }
 
 func (b *ChainBuilder) Build(ctx context.Context) (*Chain, error) {
+	if _, err := flags.ParseGasSetting(b.gas); err != nil {
+		return nil, fmt.Errorf("invalid gas %q: %w", b.gas, err)
+	}
+
 	registry := codectypes.NewInterfaceRegistry()
 	cryptocodec.RegisterInterfaces(registry)
 	cdc := codec.NewProtoCodec(registry)
 
+	genesisBz := b.genesisBz
+	if b.exportedGenesisBz != nil {
+		var err error
+		genesisBz, err = prepareExportedGenesis(b.exportedGenesisBz, b.chainID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	nodes, err := b.initializeChainNodes(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize chain nodes: %w", err)
@@ -431,23 +508,27 @@ func (b *ChainBuilder) Build(ctx context.Context) (*Chain, error) {
 
 	chain := &Chain{
 		Config: ChainConfig{
-			Logger:              b.logger,
-			DockerClient:        b.dockerClient,
-			DockerNetworkID:     b.dockerNetworkID,
-			Name:                b.name,
-			ChainID:             b.chainID,
-			Image:               *b.dockerImage, // default image must be provided, can be overridden per node.
-			Bin:                 b.binaryName,
-			Bech32Prefix:        b.bech32Prefix,
-			Denom:               b.denom,
-			CoinType:            b.coinType,
-			GasPrices:           b.gasPrices,
-			GasAdjustment:       b.gasAdjustment,
-			PostInit:            b.postInits,
-			EncodingConfig:      b.encodingConfig,
-			AdditionalStartArgs: b.additionalStartArgs,
-			Env:                 b.env,
-			GenesisFileBz:       b.genesisBz,
+			Logger:               b.logger,
+			DockerClient:         b.dockerClient,
+			DockerNetworkID:      b.dockerNetworkID,
+			Name:                 b.name,
+			ChainID:              b.chainID,
+			Image:                *b.dockerImage, // default image must be provided, can be overridden per node.
+			Bin:                  b.binaryName,
+			Bech32Prefix:         b.bech32Prefix,
+			Denom:                b.denom,
+			CoinType:             b.coinType,
+			GasPrices:            b.gasPrices,
+			GasAdjustment:        b.gasAdjustment,
+			Gas:                  b.gas,
+			GasPriceSource:       b.gasPriceSource,
+			TrustingPeriod:       b.trustingPeriod,
+			PostInit:             b.postInits,
+			EncodingConfig:       b.encodingConfig,
+			AdditionalStartArgs:  b.additionalStartArgs,
+			Env:                  b.env,
+			GenesisFileBz:        genesisBz,
+			GenesisModifications: b.genesisModifications,
 		},
 		t:                b.t,
 		Validators:       validators,
@@ -456,6 +537,7 @@ func (b *ChainBuilder) Build(ctx context.Context) (*Chain, error) {
 		log:              b.logger,
 		faucetWallet:     b.faucetWallet,
 		skipInit:         b.skipInit,
+		existingVolumes:  b.existingVolumes,
 		blockWaitTimeout: b.blockWaitTimeout,
 	}
 
@@ -485,6 +567,7 @@ func (b *ChainBuilder) newChainNode(
 	// Construct the ChainNode first so we can access its name.
 	// The ChainNode's VolumeName cannot be set until after we create the volume.
 	tn := b.newDockerChainNode(b.logger, nodeConfig, index)
+	artifacts.Register(b.dockerClient.CleanupLabel(), tn)
 
 	// create and setup volume using shared logic
 	if err := tn.CreateAndSetupVolume(ctx, tn.Name()); err != nil {
@@ -575,6 +658,17 @@ func preloadKeyringToVolume(ctx context.Context, node *ChainNode, nodeConfig Cha
 		return fmt.Errorf("failed to import key into temp keyring: %w", err)
 	}
 
+	// the faucet key is preloaded as well when present so that the chain's faucet wallet can be loaded from the node.
+	if _, err := node.GenesisKeyring.Key(consts.FaucetAccountKeyName); err == nil && validatorKeyName != consts.FaucetAccountKeyName {
+		faucetArmor, err := node.GenesisKeyring.ExportPrivKeyArmor(consts.FaucetAccountKeyName, "")
+		if err != nil {
+			return fmt.Errorf("failed to export faucet key: %w", err)
+		}
+		if err := tempKeyring.ImportPrivKey(consts.FaucetAccountKeyName, faucetArmor, ""); err != nil {
+			return fmt.Errorf("failed to import faucet key into temp keyring: %w", err)
+		}
+	}
+
 	// copy keyring files to the volume.
 	return copyKeyringFilesToVolume(ctx, node, tempDir)
 }
@@ -3,6 +3,7 @@ package cosmos
 import (
 	"context"
 	"github.com/celestiaorg/tastora/framework/docker/container"
+	"github.com/celestiaorg/tastora/framework/testutil/maps"
 	"github.com/celestiaorg/tastora/framework/types"
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"go.uber.org/zap"
@@ -37,8 +38,12 @@ type ChainConfig struct {
 	// Adjustment multiplier for gas fees.
 	GasAdjustment float64
 	// Default gas limit for transactions. May be empty, "auto", or a number.
+	// With "auto" the gas limit is the gas used by simulating the transaction multiplied by GasAdjustment.
 	Gas string
-	// Trusting period of the chain.
+	// GasPriceSource determines the gas price transactions are signed with, GasPrices by default.
+	GasPriceSource GasPriceSource
+	// Trusting period of the light clients of the chain created by relayers, e.g. "2m". Relayers use their
+	// default if it is empty.
 	TrustingPeriod string
 	// PostInit defines a set of functions executed after initializing a chain node, allowing custom setups or configurations.
 	PostInit []func(ctx context.Context, chainNode *ChainNode) error
@@ -50,4 +55,6 @@ type ChainConfig struct {
 	Env []string
 	// GenesisFileBz contains the raw bytes of the genesis file that will be written to config/gensis.json
 	GenesisFileBz []byte
+	// GenesisModifications are applied to the genesis file before it is written to the nodes.
+	GenesisModifications []maps.Entry
 }
@@ -0,0 +1,20 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReferenceNode verifies that validators are preferred as reference nodes over full nodes.
+func TestReferenceNode(t *testing.T) {
+	validator1, validator2, fullNode := &ChainNode{}, &ChainNode{}, &ChainNode{}
+	chain := &Chain{Validators: ChainNodes{validator1, validator2}, FullNodes: ChainNodes{fullNode}}
+
+	require.Same(t, validator1, chain.referenceNode(fullNode))
+	require.Same(t, validator2, chain.referenceNode(validator1))
+
+	chain = &Chain{Validators: ChainNodes{validator1}, FullNodes: ChainNodes{fullNode}}
+	require.Same(t, fullNode, chain.referenceNode(validator1))
+	require.Nil(t, (&Chain{Validators: ChainNodes{validator1}}).referenceNode(validator1))
+}
@@ -0,0 +1,198 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/docker/consts"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"go.uber.org/zap"
+)
+
+// exportedGenesisFile is the file within the node's home directory the exported state is written to.
+const exportedGenesisFile = "exported_genesis.json"
+
+// ExportState exports the application state of the chain at the given height as a genesis file using the
+// binary's export command. A height of 0 exports the latest committed height, other heights must not have
+// been pruned by the node.
+//
+// The export is run against a full node if the chain has one, otherwise against the first validator.
+// The node is stopped while the export runs in a one-shot container sharing its volume, and is restarted
+// afterwards. ExportState returns once the node has caught up with the rest of the chain again.
+func (c *Chain) ExportState(ctx context.Context, height int64) ([]byte, error) {
+	n := c.exportNode()
+	if n == nil {
+		return nil, fmt.Errorf("chain %s has no nodes to export state from", c.GetChainID())
+	}
+
+	if err := n.Remove(ctx, types.WithPreserveVolumes()); err != nil {
+		return nil, fmt.Errorf("failed to stop node %s for export: %w", n.Name(), err)
+	}
+
+	exported, exportErr := n.ExportState(ctx, height)
+
+	if err := c.restartNode(ctx, n); err != nil {
+		return nil, fmt.Errorf("failed to restart node %s after export: %w", n.Name(), err)
+	}
+	if exportErr != nil {
+		return nil, exportErr
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, c.getBlockWaitTimeout())
+	defer cancel()
+
+	reference := c.referenceNode(n)
+	if reference == nil {
+		if err := wait.ForBlocks(syncCtx, 2, n); err != nil {
+			return nil, fmt.Errorf("node %s did not produce blocks after export: %w", n.Name(), err)
+		}
+	} else if err := wait.ForInSync(syncCtx, reference, n); err != nil {
+		return nil, fmt.Errorf("node %s did not sync with %s after export: %w", n.Name(), reference.Name(), err)
+	}
+
+	c.log.Info("exported chain state", zap.String("node", n.Name()), zap.Int64("height", height))
+	return exported, nil
+}
+
+// exportNode returns the node state is exported from, preferring full nodes so that validators keep signing.
+func (c *Chain) exportNode() *ChainNode {
+	if len(c.FullNodes) > 0 {
+		return c.FullNodes[0]
+	}
+	if len(c.Validators) > 0 {
+		return c.Validators[0]
+	}
+	return nil
+}
+
+// ExportState runs the binary's export command against the node's home directory and returns the exported genesis.
+// A height of 0 exports the latest committed height. The node must be stopped as the export needs exclusive
+// access to its data directory.
+func (cn *ChainNode) ExportState(ctx context.Context, height int64) ([]byte, error) {
+	cmd := []string{"export", "--output-document", cn.HomeDir() + "/" + exportedGenesisFile}
+	if height > 0 {
+		cmd = append(cmd, "--height", strconv.FormatInt(height, 10))
+	}
+
+	if _, _, err := cn.execBin(ctx, cmd...); err != nil {
+		return nil, fmt.Errorf("failed to export state of node %s: %w", cn.Name(), err)
+	}
+
+	exported, err := cn.ReadFile(ctx, exportedGenesisFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exported state: %w", err)
+	}
+	if !json.Valid(exported) {
+		return nil, fmt.Errorf("exported state of node %s is not valid JSON", cn.Name())
+	}
+	return exported, nil
+}
+
+// ReadPrivValidatorKey returns the contents of the node's priv_validator_key.json.
+func (cn *ChainNode) ReadPrivValidatorKey(ctx context.Context) ([]byte, error) {
+	return cn.ReadFile(ctx, "config/priv_validator_key.json")
+}
+
+// ExportNodeConfigs returns a node config for every node of the chain which, combined with a genesis exported
+// by ExportState and passed to ChainBuilder.WithExportedGenesis, recreates the node in a new chain.
+// Validators keep their consensus key, their operator key and the faucet key, so that the new chain continues
+// with the exported validator set and the faucet wallet remains usable.
+//
+// The keys are read from the running containers, so ExportNodeConfigs must be called before the chain is removed.
+func (c *Chain) ExportNodeConfigs(ctx context.Context) ([]ChainNodeConfig, error) {
+	var configs []ChainNodeConfig
+	for _, v := range c.Validators {
+		cfg, err := v.exportValidatorConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export config of validator %s: %w", v.Name(), err)
+		}
+		configs = append(configs, cfg)
+	}
+	for _, n := range c.FullNodes {
+		configs = append(configs, NewChainNodeConfigBuilder().
+			WithNodeType(types.NodeTypeConsensusFull).
+			WithImage(n.Image).
+			WithAdditionalStartArgs(n.AdditionalStartArgs...).
+			WithEnvVars(n.Env...).
+			Build())
+	}
+	return configs, nil
+}
+
+// exportValidatorConfig returns a node config carrying the consensus key and keyring of the validator.
+func (cn *ChainNode) exportValidatorConfig(ctx context.Context) (ChainNodeConfig, error) {
+	privValKey, err := cn.ReadPrivValidatorKey(ctx)
+	if err != nil {
+		return ChainNodeConfig{}, err
+	}
+
+	nodeKeyring, err := cn.GetKeyring()
+	if err != nil {
+		return ChainNodeConfig{}, fmt.Errorf("failed to get keyring: %w", err)
+	}
+	records, err := nodeKeyring.List()
+	if err != nil {
+		return ChainNodeConfig{}, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	kr := keyring.NewInMemory(cn.EncodingConfig.Codec)
+	accountName := ""
+	for _, r := range records {
+		armor, err := nodeKeyring.ExportPrivKeyArmor(r.Name, "")
+		if err != nil {
+			return ChainNodeConfig{}, fmt.Errorf("failed to export key %q: %w", r.Name, err)
+		}
+		if err := kr.ImportPrivKey(r.Name, armor, ""); err != nil {
+			return ChainNodeConfig{}, fmt.Errorf("failed to import key %q: %w", r.Name, err)
+		}
+		// the operator key is named after valKey unless the validator was created from a genesis keyring,
+		// in which case it is the only key besides the faucet and test wallets.
+		if r.Name == valKey || (accountName == "" && r.Name != consts.FaucetAccountKeyName) {
+			accountName = r.Name
+		}
+	}
+	if accountName == "" {
+		return ChainNodeConfig{}, fmt.Errorf("no operator key found in keyring")
+	}
+
+	return NewChainNodeConfigBuilder().
+		WithNodeType(types.NodeTypeValidator).
+		WithImage(cn.Image).
+		WithAdditionalStartArgs(cn.AdditionalStartArgs...).
+		WithEnvVars(cn.Env...).
+		WithPrivValidatorKey(privValKey).
+		WithKeyring(kr).
+		WithAccountName(accountName).
+		Build(), nil
+}
+
+// prepareExportedGenesis makes a genesis exported from another chain startable under the given chain ID.
+// The genesis time is moved to now so that the first block is not produced in the past, every other
+// field, including the initial height set by the export, is preserved.
+func prepareExportedGenesis(exported []byte, chainID string, now time.Time) ([]byte, error) {
+	var genesis map[string]json.RawMessage
+	if err := json.Unmarshal(exported, &genesis); err != nil {
+		return nil, fmt.Errorf("failed to parse exported genesis: %w", err)
+	}
+	if _, ok := genesis["app_state"]; !ok {
+		return nil, fmt.Errorf("exported genesis has no app_state")
+	}
+
+	chainIDBz, err := json.Marshal(chainID)
+	if err != nil {
+		return nil, err
+	}
+	genesisTimeBz, err := json.Marshal(now.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, err
+	}
+	genesis["chain_id"] = chainIDBz
+	genesis["genesis_time"] = genesisTimeBz
+
+	return json.MarshalIndent(genesis, "", "  ")
+}
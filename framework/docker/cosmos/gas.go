@@ -0,0 +1,133 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/go-square/v3/share"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdktx "github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// pfbGasFixedCost is the gas consumed by a MsgPayForBlobs besides the gas consumed for its blobs, see
+	// celestia-app's x/blob.
+	pfbGasFixedCost = 75_000
+	// bytesPerBlobInfo is the number of bytes a blob adds to the transaction besides its data.
+	bytesPerBlobInfo = 70
+)
+
+// GasPriceSource determines the gas price transactions are signed with.
+type GasPriceSource string
+
+const (
+	// GasPriceSourceConfig uses the gas prices of the chain config.
+	GasPriceSourceConfig GasPriceSource = ""
+	// GasPriceSourceNodeMinimum uses the minimum gas price of the node the transaction is broadcast through.
+	GasPriceSourceNodeMinimum GasPriceSource = "node-minimum"
+	// GasPriceSourceEstimated uses the gas price estimated by celestia-app's gas estimation service from the
+	// transactions in the mempool, which is at least the network minimum gas price.
+	GasPriceSourceEstimated GasPriceSource = "estimated"
+)
+
+// GasPrices returns the gas prices transactions are signed with according to the GasPriceSource of the chain
+// config, e.g. "0.002utia". The source is queried through the first node of the chain.
+func (c *Chain) GasPrices(ctx context.Context) (string, error) {
+	return c.GetNode().gasPrices(ctx, c.Config.GasPriceSource, c.Config.Denom)
+}
+
+// gasPrices returns the gas prices transactions broadcast through the node are signed with for the source.
+// Sources without a price, such as a node without a minimum gas price, fall back to the gas prices of the node.
+func (cn *ChainNode) gasPrices(ctx context.Context, source GasPriceSource, denom string) (string, error) {
+	switch source {
+	case GasPriceSourceConfig:
+		return cn.GasPrices, nil
+	case GasPriceSourceNodeMinimum:
+		prices, err := query.MinGasPrices(ctx, cn.GrpcConn)
+		if err != nil {
+			return "", err
+		}
+		if prices.IsZero() {
+			return cn.GasPrices, nil
+		}
+		return prices.String(), nil
+	case GasPriceSourceEstimated:
+		price, err := query.EstimateGasPrice(ctx, cn.GrpcConn, query.TxPriorityMedium)
+		if err != nil {
+			return "", err
+		}
+		if price == 0 {
+			return cn.GasPrices, nil
+		}
+		dec, err := sdkmath.LegacyNewDecFromStr(fmt.Sprintf("%.18f", price))
+		if err != nil {
+			return "", fmt.Errorf("failed to parse estimated gas price %v: %w", price, err)
+		}
+		return sdk.NewDecCoinFromDec(denom, dec).String(), nil
+	default:
+		return "", fmt.Errorf("unknown gas price source %q", source)
+	}
+}
+
+// EstimateBlobGas returns the gas consumed by a MsgPayForBlobs paying for the blobs, given the gas per blob byte
+// of the blob module and the tx size cost per byte of the auth module. Like celestia-app, blobs are charged for
+// every share they occupy rather than for their size.
+func EstimateBlobGas(gasPerBlobByte uint32, txSizeCostPerByte uint64, blobs ...*share.Blob) uint64 {
+	var shares uint64
+	for _, blob := range blobs {
+		shares += uint64(share.SparseSharesNeeded(uint32(blob.DataLen()), blob.HasSigner()))
+	}
+	return shares*share.ShareSize*uint64(gasPerBlobByte) + txSizeCostPerByte*bytesPerBlobInfo*uint64(len(blobs)) + pfbGasFixedCost
+}
+
+// estimateBlobGas returns the gas consumed by a MsgPayForBlobs paying for the blobs, with the parameters of the
+// chain queried through the node.
+func (cn *ChainNode) estimateBlobGas(ctx context.Context, blobs []*share.Blob) (uint64, error) {
+	blobParams, err := query.BlobModuleParams(ctx, cn.GrpcConn)
+	if err != nil {
+		return 0, err
+	}
+	authParams, err := query.AuthParams(ctx, cn.GrpcConn)
+	if err != nil {
+		return 0, err
+	}
+	return EstimateBlobGas(blobParams.GasPerBlobByte, authParams.TxSizeCostPerByte, blobs...), nil
+}
+
+// configureGas sets the gas prices of the factory from the gas price source of the chain and, for transactions
+// paying for blobs which are not simulated, raises the gas limit to the adjusted gas consumed by the blobs.
+func (cn *ChainNode) configureGas(ctx context.Context, cfg ChainConfig, txf sdktx.Factory, blobs []*share.Blob) (sdktx.Factory, error) {
+	if cfg.GasPriceSource != GasPriceSourceConfig {
+		gasPrices, err := cn.gasPrices(ctx, cfg.GasPriceSource, cfg.Denom)
+		if err != nil {
+			return sdktx.Factory{}, fmt.Errorf("failed to get gas prices: %w", err)
+		}
+		txf = txf.WithGasPrices(gasPrices)
+	}
+
+	if len(blobs) == 0 || txf.SimulateAndExecute() {
+		return txf, nil
+	}
+	blobGas, err := cn.estimateBlobGas(ctx, blobs)
+	if err != nil {
+		return sdktx.Factory{}, fmt.Errorf("failed to estimate blob gas: %w", err)
+	}
+	return txf.WithGas(max(txf.Gas(), uint64(float64(blobGas)*txf.GasAdjustment()))), nil
+}
+
+// simulateGas sets the gas limit of a factory which simulates transactions to the adjusted gas used by simulating
+// the messages. The gas of a MsgPayForBlobs is consumed for the blob sizes it declares, so simulating it does not
+// require the blobs.
+func simulateGas(cc client.Context, txf sdktx.Factory, msgs ...sdk.Msg) (sdktx.Factory, error) {
+	if !txf.SimulateAndExecute() {
+		return txf, nil
+	}
+	_, gas, err := sdktx.CalculateGas(cc, txf, msgs...)
+	if err != nil {
+		return sdktx.Factory{}, fmt.Errorf("failed to simulate tx: %w", err)
+	}
+	return txf.WithGas(gas), nil
+}
@@ -0,0 +1,26 @@
+package cosmos
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/go-square/v3/share"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEstimateBlobGas verifies that the gas of blobs is charged for every share they occupy.
+func TestEstimateBlobGas(t *testing.T) {
+	ns := share.MustNewV0Namespace([]byte("tastora"))
+	small, err := share.NewV0Blob(ns, []byte("hello"))
+	require.NoError(t, err)
+	large, err := share.NewV0Blob(ns, bytes.Repeat([]byte{1}, 100_000))
+	require.NoError(t, err)
+
+	// a blob smaller than a share occupies a full share.
+	require.Equal(t, uint64(share.ShareSize*8+10*bytesPerBlobInfo+pfbGasFixedCost), EstimateBlobGas(8, 10, small))
+	// the large blob needs more gas than the default gas limit.
+	require.Greater(t, EstimateBlobGas(8, 10, large), uint64(flags.DefaultGasLimit))
+	require.Equal(t, EstimateBlobGas(8, 10, small)+EstimateBlobGas(8, 10, large)-pfbGasFixedCost,
+		EstimateBlobGas(8, 10, small, large))
+}
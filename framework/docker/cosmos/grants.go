@@ -0,0 +1,194 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/x/feegrant"
+	"github.com/celestiaorg/tastora/framework/testutil/events"
+	"github.com/celestiaorg/tastora/framework/types"
+	sdktx "github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// WithFeeGranter returns a FactoryOpt which pays the fees of the transaction from the fee allowance granted to
+// the signer by the granter, see GrantFeeAllowance.
+func WithFeeGranter(granter *types.Wallet) types.FactoryOpt {
+	return func(factory sdktx.Factory) sdktx.Factory {
+		return factory.WithFeeGranter(sdk.AccAddress(granter.Address))
+	}
+}
+
+// WithFeePayer returns a FactoryOpt which pays the fees of the transaction from the account of the payer.
+// The payer has to sign the transaction as well, see BroadcastMessagesWithFeePayer.
+func WithFeePayer(payer *types.Wallet) types.FactoryOpt {
+	return func(factory sdktx.Factory) sdktx.Factory {
+		return factory.WithFeePayer(sdk.AccAddress(payer.Address))
+	}
+}
+
+// FeeAllowance configures the fee allowance granted with GrantFeeAllowance.
+// The zero value grants an unlimited allowance without expiration for any message.
+type FeeAllowance struct {
+	// SpendLimit is the maximum amount of fees the grantee can spend, unlimited if empty.
+	SpendLimit sdk.Coins
+	// Expiration is the time at which the allowance expires, never if zero.
+	Expiration time.Time
+	// AllowedMessages restricts the allowance to the given message type URLs, e.g. sdk.MsgTypeURL(&banktypes.MsgSend{}).
+	AllowedMessages []string
+}
+
+// GrantFeeAllowance grants the grantee an allowance to pay transaction fees from the account of the granter.
+func (c *Chain) GrantFeeAllowance(ctx context.Context, granter, grantee *types.Wallet, allowance FeeAllowance) error {
+	basic := &feegrant.BasicAllowance{SpendLimit: allowance.SpendLimit}
+	if !allowance.Expiration.IsZero() {
+		expiration := allowance.Expiration
+		basic.Expiration = &expiration
+	}
+
+	var feeAllowance feegrant.FeeAllowanceI = basic
+	if len(allowance.AllowedMessages) > 0 {
+		allowed, err := feegrant.NewAllowedMsgAllowance(basic, allowance.AllowedMessages)
+		if err != nil {
+			return fmt.Errorf("failed to create allowed message allowance: %w", err)
+		}
+		feeAllowance = allowed
+	}
+
+	msg, err := feegrant.NewMsgGrantAllowance(feeAllowance, sdk.AccAddress(granter.Address), sdk.AccAddress(grantee.Address))
+	if err != nil {
+		return fmt.Errorf("failed to create fee allowance grant: %w", err)
+	}
+	if err := c.broadcastCommitted(ctx, granter, msg); err != nil {
+		return fmt.Errorf("failed to grant fee allowance to %s: %w", grantee.GetFormattedAddress(), err)
+	}
+	return nil
+}
+
+// RevokeFeeAllowance revokes the fee allowance granted to the grantee by the granter.
+func (c *Chain) RevokeFeeAllowance(ctx context.Context, granter, grantee *types.Wallet) error {
+	msg := feegrant.NewMsgRevokeAllowance(sdk.AccAddress(granter.Address), sdk.AccAddress(grantee.Address))
+	if err := c.broadcastCommitted(ctx, granter, &msg); err != nil {
+		return fmt.Errorf("failed to revoke fee allowance of %s: %w", grantee.GetFormattedAddress(), err)
+	}
+	return nil
+}
+
+// BroadcastMessagesWithFeeGranter broadcasts the messages signed by the signer, paying the fees from the
+// allowance granted to the signer by the granter.
+func (c *Chain) BroadcastMessagesWithFeeGranter(ctx context.Context, signer, granter *types.Wallet, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	node, _, err := c.nodeWithKey(signer)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	b := newBroadcasterForNode(c, node)
+	b.ConfigureFactoryOptions(WithFeeGranter(granter))
+	return b.BroadcastMessages(ctx, signer, msgs...)
+}
+
+// BroadcastMessagesWithFeePayer broadcasts the messages signed by the signer, paying the fees from the account
+// of the payer which signs the transaction as well. The transaction is signed in amino JSON sign mode as the
+// direct sign mode does not support signing by several keys independently.
+func (c *Chain) BroadcastMessagesWithFeePayer(ctx context.Context, signer, payer *types.Wallet, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	txBuilder, err := c.buildUnsignedTx(msgs, WithFeePayer(payer))
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	// the order of signatures has to match the signers of the transaction, the fee payer comes last.
+	for _, w := range []*types.Wallet{signer, payer} {
+		_, txf, err := c.signingFactory(w, w.Address)
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+		txf = txf.WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+		if err := sdktx.Sign(ctx, txf, w.GetKeyName(), txBuilder, false); err != nil {
+			return sdk.TxResponse{}, fmt.Errorf("failed to sign tx with %s: %w", w.GetFormattedAddress(), err)
+		}
+	}
+
+	txBytes, err := c.Config.EncodingConfig.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return sdk.TxResponse{}, fmt.Errorf("failed to encode tx: %w", err)
+	}
+	return c.BroadcastRawTx(ctx, txBytes)
+}
+
+// GrantAuthorization grants the grantee the authorization to execute messages on behalf of the granter.
+// A nil expiration grants the authorization without expiration.
+func (c *Chain) GrantAuthorization(ctx context.Context, granter, grantee *types.Wallet, authorization authz.Authorization, expiration *time.Time) (sdk.TxResponse, error) {
+	msg, err := authz.NewMsgGrant(sdk.AccAddress(granter.Address), sdk.AccAddress(grantee.Address), authorization, expiration)
+	if err != nil {
+		return sdk.TxResponse{}, fmt.Errorf("failed to create grant: %w", err)
+	}
+	resp, err := c.broadcastFrom(ctx, granter, msg)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	if err := events.TxError(resp); err != nil {
+		return resp, fmt.Errorf("failed to grant authorization to %s: %w", grantee.GetFormattedAddress(), err)
+	}
+	return resp, nil
+}
+
+// GrantGenericAuthorization grants the grantee the authorization to execute messages of the given type URL,
+// e.g. sdk.MsgTypeURL(&banktypes.MsgSend{}), on behalf of the granter without expiration.
+func (c *Chain) GrantGenericAuthorization(ctx context.Context, granter, grantee *types.Wallet, msgTypeURL string) (sdk.TxResponse, error) {
+	return c.GrantAuthorization(ctx, granter, grantee, authz.NewGenericAuthorization(msgTypeURL), nil)
+}
+
+// RevokeAuthorization revokes the authorization of the grantee to execute messages of the given type URL on
+// behalf of the granter.
+func (c *Chain) RevokeAuthorization(ctx context.Context, granter, grantee *types.Wallet, msgTypeURL string) (sdk.TxResponse, error) {
+	msg := authz.NewMsgRevoke(sdk.AccAddress(granter.Address), sdk.AccAddress(grantee.Address), msgTypeURL)
+	resp, err := c.broadcastFrom(ctx, granter, &msg)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	if err := events.TxError(resp); err != nil {
+		return resp, fmt.Errorf("failed to revoke authorization of %s: %w", grantee.GetFormattedAddress(), err)
+	}
+	return resp, nil
+}
+
+// NewMsgExec wraps the messages in a MsgExec executed by the grantee. The signers of the messages are the
+// granters on whose behalf the messages are executed.
+func NewMsgExec(grantee *types.Wallet, msgs ...sdk.Msg) *authz.MsgExec {
+	msg := authz.NewMsgExec(sdk.AccAddress(grantee.Address), msgs)
+	return &msg
+}
+
+// ExecAuthorized broadcasts the messages wrapped in a MsgExec signed by the grantee, executing them on behalf
+// of the granters which have granted the grantee the authorization to do so.
+func (c *Chain) ExecAuthorized(ctx context.Context, grantee *types.Wallet, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	return c.broadcastFrom(ctx, grantee, NewMsgExec(grantee, msgs...))
+}
+
+// QueryGrants returns the authorizations granted to the grantee by the granter. An empty msgTypeURL returns
+// the grants of all message types.
+func (c *Chain) QueryGrants(ctx context.Context, granter, grantee *types.Wallet, msgTypeURL string) ([]*authz.Grant, error) {
+	res, err := authz.NewQueryClient(c.GetNode().GrpcConn).Grants(ctx, &authz.QueryGrantsRequest{
+		Granter:    granter.GetFormattedAddress(),
+		Grantee:    grantee.GetFormattedAddress(),
+		MsgTypeUrl: msgTypeURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query grants of %s: %w", grantee.GetFormattedAddress(), err)
+	}
+	return res.Grants, nil
+}
+
+// broadcastCommitted broadcasts the messages signed by the wallet and returns an error if the transaction failed.
+func (c *Chain) broadcastCommitted(ctx context.Context, wallet *types.Wallet, msgs ...sdk.Msg) error {
+	resp, err := c.broadcastFrom(ctx, wallet, msgs...)
+	if err != nil {
+		return err
+	}
+	if err := events.TxError(resp); err != nil {
+		return fmt.Errorf("tx %s failed: %w", resp.TxHash, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+)
+
+// RecoverClient submits a MsgRecoverClient governance proposal and ensures that it passes. The expired or frozen
+// subject client is then active again, continuing from the state of the substitute client, which has to be an
+// active client of the same chain created after the subject client, e.g. with Hermes.CreateClient.
+func (c *Chain) RecoverClient(ctx context.Context, subjectClientID, substituteClientID string) error {
+	msg := &clienttypes.MsgRecoverClient{
+		SubjectClientId:    subjectClientID,
+		SubstituteClientId: substituteClientID,
+		Signer:             authtypes.NewModuleAddress("gov").String(),
+	}
+
+	anyMsg, err := codectypes.NewAnyWithValue(msg)
+	if err != nil {
+		return fmt.Errorf("failed to pack recover client message: %w", err)
+	}
+
+	proposal := &govv1.MsgSubmitProposal{
+		Messages:       []*codectypes.Any{anyMsg},
+		InitialDeposit: sdk.NewCoins(sdk.NewCoin(c.Config.Denom, sdkmath.NewInt(1000))),
+		Proposer:       c.GetFaucetWallet().GetFormattedAddress(),
+		Title:          fmt.Sprintf("Recover client %s", subjectClientID),
+		Summary:        fmt.Sprintf("Recover client %s with substitute client %s", subjectClientID, substituteClientID),
+	}
+
+	prop, err := c.SubmitAndVoteOnGovV1Proposal(ctx, proposal, govv1.VoteOption_VOTE_OPTION_YES)
+	if err != nil {
+		return fmt.Errorf("failed to submit recover client proposal: %w", err)
+	}
+
+	if prop.Status != govv1.ProposalStatus_PROPOSAL_STATUS_PASSED {
+		return fmt.Errorf("recover client proposal %d did not pass: %s", prop.Id, prop.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdktx "github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// MultisigWallet is a wallet whose account is controlled by a legacy amino multisig key.
+// Transactions of the account require signatures from at least Threshold of the member keys.
+type MultisigWallet struct {
+	*types.Wallet
+	PubKey *kmultisig.LegacyAminoPubKey
+}
+
+// Threshold returns the number of member signatures required to sign for the multisig account.
+func (w *MultisigWallet) Threshold() int {
+	return int(w.PubKey.Threshold)
+}
+
+// CreateMultisigWallet creates a legacy amino multisig key from the public keys of the member wallets and
+// stores it in the keyring of the first node under keyName. As with `keys add --multisig`, the member keys are
+// sorted by address so that the resulting address does not depend on the order of the members.
+// The account must be funded before it can sign transactions.
+func (c *Chain) CreateMultisigWallet(ctx context.Context, keyName string, threshold int, members ...*types.Wallet) (*MultisigWallet, error) {
+	if threshold <= 0 || threshold > len(members) {
+		return nil, fmt.Errorf("invalid threshold %d for %d members", threshold, len(members))
+	}
+
+	pubKeys := make([]cryptotypes.PubKey, 0, len(members))
+	for _, m := range members {
+		_, kr, err := c.nodeWithKey(m)
+		if err != nil {
+			return nil, err
+		}
+		record, err := kr.KeyByAddress(sdk.AccAddress(m.Address))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key of %s: %w", m.GetFormattedAddress(), err)
+		}
+		pk, err := record.GetPubKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key of %s: %w", m.GetFormattedAddress(), err)
+		}
+		pubKeys = append(pubKeys, pk)
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i].Address(), pubKeys[j].Address()) < 0
+	})
+
+	pubKey := kmultisig.NewLegacyAminoPubKey(threshold, pubKeys)
+
+	kr, err := c.GetNode().GetKeyring()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keyring: %w", err)
+	}
+	if _, err := kr.SaveMultisig(keyName, pubKey); err != nil {
+		return nil, fmt.Errorf("failed to save multisig key %q: %w", keyName, err)
+	}
+
+	addr := pubKey.Address().Bytes()
+	formattedAddress := sdk.MustBech32ifyAddressBytes(c.Config.Bech32Prefix, addr)
+	return &MultisigWallet{
+		Wallet: types.NewWallet(addr, formattedAddress, c.Config.Bech32Prefix, keyName),
+		PubKey: pubKey,
+	}, nil
+}
+
+// GenerateTx builds an unsigned transaction of the messages and returns it JSON encoded, equivalent to
+// `tx ... --generate-only`. The fee is derived from the default gas limit and the gas prices of the chain.
+func (c *Chain) GenerateTx(_ context.Context, msgs ...sdk.Msg) ([]byte, error) {
+	txBuilder, err := c.buildUnsignedTx(msgs)
+	if err != nil {
+		return nil, err
+	}
+	return c.Config.EncodingConfig.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// SignTx signs the JSON encoded transaction with the key of the wallet, using the current account number and
+// sequence of the wallet's account, and returns the signed transaction JSON encoded.
+func (c *Chain) SignTx(ctx context.Context, signer *types.Wallet, txJSON []byte) ([]byte, error) {
+	cc, txf, err := c.signingFactory(signer, signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	txBuilder, err := c.decodeTx(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sdktx.Sign(ctx, txf, signer.GetKeyName(), txBuilder, true); err != nil {
+		return nil, fmt.Errorf("failed to sign tx with %s: %w", signer.GetFormattedAddress(), err)
+	}
+	return cc.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// SignMultisigTx returns the partial signature of a member of the multisig wallet for the JSON encoded
+// transaction of the multisig account, equivalent to `tx sign --multisig`. Partial signatures are combined with
+// CombineMultisigTx once the threshold is met.
+func (c *Chain) SignMultisigTx(ctx context.Context, multisigWallet *MultisigWallet, member *types.Wallet, txJSON []byte) (signing.SignatureV2, error) {
+	_, txf, err := c.signingFactory(member, multisigWallet.Address)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	// multisig signatures are only supported with amino JSON signing.
+	txf = txf.WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+
+	txBuilder, err := c.decodeTx(txJSON)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+
+	if err := sdktx.Sign(ctx, txf, member.GetKeyName(), txBuilder, true); err != nil {
+		return signing.SignatureV2{}, fmt.Errorf("failed to sign tx with %s: %w", member.GetFormattedAddress(), err)
+	}
+
+	sigs, err := txBuilder.GetTx().GetSignaturesV2()
+	if err != nil {
+		return signing.SignatureV2{}, fmt.Errorf("failed to get signatures: %w", err)
+	}
+	if len(sigs) != 1 {
+		return signing.SignatureV2{}, fmt.Errorf("expected a single signature, got %d", len(sigs))
+	}
+	return sigs[0], nil
+}
+
+// CombineMultisigTx combines the partial signatures of the members into the signature of the multisig account
+// and returns the signed transaction JSON encoded, equivalent to `tx multisign`.
+func (c *Chain) CombineMultisigTx(_ context.Context, multisigWallet *MultisigWallet, txJSON []byte, sigs ...signing.SignatureV2) ([]byte, error) {
+	if len(sigs) < multisigWallet.Threshold() {
+		return nil, fmt.Errorf("got %d signatures, the threshold is %d", len(sigs), multisigWallet.Threshold())
+	}
+
+	cc := c.GetNode().CliContext()
+	_, sequence, err := AccountRetriever{chain: c, prefix: c.Config.Bech32Prefix}.GetAccountNumberSequence(cc, multisigWallet.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account of %s: %w", multisigWallet.GetFormattedAddress(), err)
+	}
+
+	txBuilder, err := c.decodeTx(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	multisigSig := multisig.NewMultisig(len(multisigWallet.PubKey.PubKeys))
+	for _, sig := range sigs {
+		if err := multisig.AddSignatureV2(multisigSig, sig, multisigWallet.PubKey.GetPubKeys()); err != nil {
+			return nil, fmt.Errorf("failed to add signature: %w", err)
+		}
+	}
+
+	sig := signing.SignatureV2{
+		PubKey:   multisigWallet.PubKey,
+		Data:     multisigSig,
+		Sequence: sequence,
+	}
+	if err := txBuilder.SetSignatures(sig); err != nil {
+		return nil, fmt.Errorf("failed to set multisig signature: %w", err)
+	}
+	return cc.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// BroadcastMultisigMessages broadcasts the messages as a transaction of the multisig account, signed by each
+// of the signers, and waits for it to be included in a block.
+func (c *Chain) BroadcastMultisigMessages(ctx context.Context, multisigWallet *MultisigWallet, signers []*types.Wallet, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	txJSON, err := c.GenerateTx(ctx, msgs...)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	sigs := make([]signing.SignatureV2, 0, len(signers))
+	for _, s := range signers {
+		sig, err := c.SignMultisigTx(ctx, multisigWallet, s, txJSON)
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+		sigs = append(sigs, sig)
+	}
+
+	signedTx, err := c.CombineMultisigTx(ctx, multisigWallet, txJSON, sigs...)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return c.BroadcastTx(ctx, signedTx)
+}
+
+// BroadcastTx broadcasts a signed JSON encoded transaction and waits for it to be included in a block.
+func (c *Chain) BroadcastTx(ctx context.Context, txJSON []byte) (sdk.TxResponse, error) {
+	txBuilder, err := c.decodeTx(txJSON)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	txBytes, err := c.Config.EncodingConfig.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return sdk.TxResponse{}, fmt.Errorf("failed to encode tx: %w", err)
+	}
+	return c.BroadcastRawTx(ctx, txBytes)
+}
+
+// BroadcastRawTx broadcasts signed protobuf encoded transaction bytes and waits for them to be included in a block.
+// An error is returned alongside the response if the transaction is rejected by CheckTx.
+func (c *Chain) BroadcastRawTx(ctx context.Context, txBytes []byte) (sdk.TxResponse, error) {
+	cc := c.GetNode().CliContext().WithBroadcastMode(flags.BroadcastSync)
+	res, err := cc.BroadcastTx(txBytes)
+	if err != nil {
+		return sdk.TxResponse{}, fmt.Errorf("failed to broadcast tx: %w", err)
+	}
+	if res.Code != 0 {
+		return *res, fmt.Errorf("tx %s failed check tx with code %d: %s", res.TxHash, res.Code, res.RawLog)
+	}
+	return getFullyPopulatedResponse(ctx, cc, res.TxHash)
+}
+
+// signingFactory returns a client context and factory signing with the keyring of a node holding the key of the
+// signer, using the account number and sequence of the account with the given address.
+func (c *Chain) signingFactory(signer *types.Wallet, account sdk.AccAddress) (client.Context, sdktx.Factory, error) {
+	node, kr, err := c.nodeWithKey(signer)
+	if err != nil {
+		return client.Context{}, sdktx.Factory{}, err
+	}
+
+	retriever := AccountRetriever{chain: c, prefix: c.Config.Bech32Prefix}
+	cc := node.CliContext().WithKeyring(kr).WithAccountRetriever(retriever)
+	accountNumber, sequence, err := retriever.GetAccountNumberSequence(cc, account)
+	if err != nil {
+		return client.Context{}, sdktx.Factory{}, fmt.Errorf("failed to get account of %s: %w", account, err)
+	}
+	txf, err := newTxFactory(c.Config, cc, accountNumber, sequence)
+	if err != nil {
+		return client.Context{}, sdktx.Factory{}, err
+	}
+	return cc, txf, nil
+}
+
+// buildUnsignedTx builds an unsigned transaction of the messages with the default factory of the chain
+// configured by the given options.
+func (c *Chain) buildUnsignedTx(msgs []sdk.Msg, opts ...types.FactoryOpt) (client.TxBuilder, error) {
+	txf, err := newTxFactory(c.Config, c.GetNode().CliContext(), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		txf = opt(txf)
+	}
+	txBuilder, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build unsigned tx: %w", err)
+	}
+	return txBuilder, nil
+}
+
+// decodeTx decodes a JSON encoded transaction into a builder.
+func (c *Chain) decodeTx(txJSON []byte) (client.TxBuilder, error) {
+	txConfig := c.Config.EncodingConfig.TxConfig
+	tx, err := txConfig.TxJSONDecoder()(txJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tx: %w", err)
+	}
+	return txConfig.WrapTxBuilder(tx)
+}
+
+// nodeWithKey returns the first node, and its keyring, which holds the key of the wallet.
+// Wallets created with CreateWallet are held by every node, operator keys only by their validator node.
+func (c *Chain) nodeWithKey(wallet *types.Wallet) (*ChainNode, keyring.Keyring, error) {
+	for _, n := range c.Nodes() {
+		kr, err := n.GetKeyring()
+		if err != nil {
+			continue
+		}
+		if _, err := kr.KeyByAddress(sdk.AccAddress(wallet.Address)); err != nil {
+			continue
+		}
+		return n, kr, nil
+	}
+	return nil, nil, fmt.Errorf("no node of chain %s holds the key of %s", c.GetChainID(), wallet.GetFormattedAddress())
+}
@@ -3,6 +3,7 @@ package cosmos
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"net"
@@ -55,6 +56,9 @@ func (cn *ChainNode) GetNetworkInfo(ctx context.Context) (types.NetworkInfo, err
 		return types.NetworkInfo{}, err
 	}
 
+	cn.portsMu.RLock()
+	defer cn.portsMu.RUnlock()
+
 	return types.NetworkInfo{
 		Internal: types.Network{
 			Hostname: cn.HostName(),
@@ -80,6 +84,10 @@ type ChainNode struct {
 
 	lock sync.Mutex
 
+	// portsMu guards the host ports, which are read concurrently by subscriptions to detect that the
+	// container has been recreated.
+	portsMu sync.RWMutex
+
 	// externalPorts are set during startContainer.
 	externalPorts types.Ports
 
@@ -266,6 +274,38 @@ func (cn *ChainNode) Height(ctx context.Context) (int64, error) {
 	return height, nil
 }
 
+// Artifacts returns the config files of the node and its CometBFT status, which includes its height.
+// It implements artifacts.Source so that they are collected when a test fails.
+func (cn *ChainNode) Artifacts(ctx context.Context) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	var errs []error
+	for _, relPath := range []string{"config/config.toml", "config/app.toml", "config/genesis.json"} {
+		bz, err := cn.ReadFile(ctx, relPath)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		files[path.Base(relPath)] = bz
+	}
+
+	if cn.Client == nil {
+		errs = append(errs, fmt.Errorf("node %s has not been started", cn.Name()))
+		return files, errors.Join(errs...)
+	}
+	res, err := cn.Client.Status(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("tendermint rpc client status: %w", err))
+		return files, errors.Join(errs...)
+	}
+	status, err := tmjson.MarshalIndent(res, "", "  ")
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to marshal status: %w", err))
+		return files, errors.Join(errs...)
+	}
+	files["status.json"] = status
+	return files, errors.Join(errs...)
+}
+
 // Exec runs a command in the node's container.
 // returns stdout, stdin, and an error if one occurred.
 func (cn *ChainNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
@@ -387,20 +427,30 @@ func (cn *ChainNode) startContainer(ctx context.Context) error {
 		return err
 	}
 
+	extraPortMappings := make(map[string]string)
+	for i, internalPort := range cn.AdditionalExposedPorts {
+		externalPort := internal.MustExtractPort(hostPorts[standardPortCount+i])
+		extraPortMappings[internalPort] = externalPort
+	}
+
+	cn.portsMu.Lock()
 	cn.externalPorts = types.Ports{
 		RPC:  internal.MustExtractPort(hostPorts[0]),
 		GRPC: internal.MustExtractPort(hostPorts[1]),
 		API:  internal.MustExtractPort(hostPorts[2]),
 		P2P:  internal.MustExtractPort(hostPorts[3]),
 	}
+	cn.extraPortMappings = extraPortMappings
+	cn.portsMu.Unlock()
 
-	cn.extraPortMappings = make(map[string]string)
-	for i, internalPort := range cn.AdditionalExposedPorts {
-		externalPort := internal.MustExtractPort(hostPorts[standardPortCount+i])
-		cn.extraPortMappings[internalPort] = externalPort
-	}
+	return cn.initClient(cn.hostRPCAddress())
+}
 
-	return cn.initClient("tcp://0.0.0.0:" + cn.externalPorts.RPC)
+// hostRPCAddress returns the address of the node's RPC on the host running the test.
+func (cn *ChainNode) hostRPCAddress() string {
+	cn.portsMu.RLock()
+	defer cn.portsMu.RUnlock()
+	return "tcp://0.0.0.0:" + cn.externalPorts.RPC
 }
 
 // initClient creates and assigns a new Tendermint RPC client to the ChainNode.
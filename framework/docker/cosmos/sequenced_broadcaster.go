@@ -0,0 +1,347 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/go-square/v3/share"
+	squaretx "github.com/celestiaorg/go-square/v3/tx"
+	dockerinternal "github.com/celestiaorg/tastora/framework/docker/internal"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdktx "github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"go.uber.org/zap"
+)
+
+// BroadcastMode controls what SequencedBroadcaster.Submit waits for before returning the hash of a transaction.
+type BroadcastMode string
+
+const (
+	// BroadcastModeSync returns once the transaction passed CheckTx and was added to the mempool.
+	BroadcastModeSync BroadcastMode = flags.BroadcastSync
+	// BroadcastModeAsync returns as soon as the transaction was sent to the node, without waiting for CheckTx.
+	BroadcastModeAsync BroadcastMode = flags.BroadcastAsync
+)
+
+// maxSequenceRetries is the number of times a transaction is re-signed after an account sequence mismatch.
+const maxSequenceRetries = 3
+
+// expectedSequencePattern matches the sequence expected by the ante handler in the log of a rejected transaction.
+var expectedSequencePattern = regexp.MustCompile(`account sequence mismatch, expected (\d+)`)
+
+// txNotFoundPattern matches the error of a tx query for a transaction which is not included in a block yet.
+var txNotFoundPattern = regexp.MustCompile(`tx \([0-9A-Fa-f]+\) not found`)
+
+var _ types.Broadcaster = &SequencedBroadcaster{}
+
+// SequencedBroadcaster broadcasts transactions without waiting for the previous transaction of the same
+// wallet to be included in a block. The account sequence of every wallet is tracked locally, so that
+// concurrent callers using the same wallet each sign with a distinct sequence, and is resynced with the
+// node when a transaction is rejected with an account sequence mismatch.
+//
+// Submit returns the hash of a transaction as soon as it was broadcast, and Confirm waits for a batch of
+// hashes to be included. The BroadcastMessages and BroadcastBlobMessage methods of types.Broadcaster do both.
+type SequencedBroadcaster struct {
+	chain *Chain
+	node  *ChainNode
+	mode  BroadcastMode
+
+	factoryOptions       []types.FactoryOpt
+	clientContextOptions []types.ClientContextOpt
+
+	mu       sync.Mutex
+	keyring  keyring.Keyring
+	accounts map[string]*accountSequence
+}
+
+// accountSequence is the locally tracked account number and next sequence of a wallet.
+// Its mutex is held while a transaction of the wallet is signed and broadcast.
+type accountSequence struct {
+	mu            sync.Mutex
+	synced        bool
+	accountNumber uint64
+	sequence      uint64
+}
+
+// NewSequencedBroadcaster returns a SequencedBroadcaster which broadcasts through the given node, or through
+// chain.GetNode() if node is nil, in BroadcastModeSync.
+func NewSequencedBroadcaster(chain *Chain, node *ChainNode) *SequencedBroadcaster {
+	if node == nil {
+		node = chain.GetNode()
+	}
+	return &SequencedBroadcaster{
+		chain:    chain,
+		node:     node,
+		mode:     BroadcastModeSync,
+		accounts: map[string]*accountSequence{},
+	}
+}
+
+// WithBroadcastMode sets what Submit waits for before returning. In BroadcastModeAsync rejected transactions
+// are only detected by Confirm, and a rejected transaction leaves a gap in the local sequence which is
+// resynced by the next submission failing with an account sequence mismatch.
+func (b *SequencedBroadcaster) WithBroadcastMode(mode BroadcastMode) *SequencedBroadcaster {
+	b.mode = mode
+	return b
+}
+
+// ConfigureFactoryOptions ensure the given configuration functions are run when building a transaction.
+// The account number and sequence are always set by the broadcaster.
+func (b *SequencedBroadcaster) ConfigureFactoryOptions(opts ...types.FactoryOpt) {
+	b.factoryOptions = append(b.factoryOptions, opts...)
+}
+
+// ConfigureClientContextOptions ensure the given configuration functions are run when building the client context.
+func (b *SequencedBroadcaster) ConfigureClientContextOptions(opts ...types.ClientContextOpt) {
+	b.clientContextOptions = append(b.clientContextOptions, opts...)
+}
+
+// BroadcastMessages broadcasts the messages signed by the wallet and waits for the transaction to be included.
+func (b *SequencedBroadcaster) BroadcastMessages(ctx context.Context, signingWallet *types.Wallet, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	hash, err := b.Submit(ctx, signingWallet, msgs...)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return b.confirmOne(ctx, hash)
+}
+
+// BroadcastBlobMessage broadcasts the message and blobs signed by the wallet as a blob transaction and waits
+// for the transaction to be included.
+func (b *SequencedBroadcaster) BroadcastBlobMessage(ctx context.Context, signingWallet *types.Wallet, msg sdk.Msg, blobs ...*share.Blob) (sdk.TxResponse, error) {
+	hash, err := b.SubmitBlob(ctx, signingWallet, msg, blobs...)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return b.confirmOne(ctx, hash)
+}
+
+// Submit signs the messages with the next sequence of the wallet, broadcasts them and returns the hash of the
+// transaction without waiting for it to be included. Use Confirm to wait for the inclusion.
+func (b *SequencedBroadcaster) Submit(ctx context.Context, signingWallet *types.Wallet, msgs ...sdk.Msg) (string, error) {
+	return b.submit(ctx, signingWallet, nil, msgs...)
+}
+
+// SubmitBlob signs the message with the next sequence of the wallet, broadcasts it with the blobs as a blob
+// transaction and returns the hash of the transaction without waiting for it to be included.
+func (b *SequencedBroadcaster) SubmitBlob(ctx context.Context, signingWallet *types.Wallet, msg sdk.Msg, blobs ...*share.Blob) (string, error) {
+	return b.submit(ctx, signingWallet, blobs, msg)
+}
+
+// Confirm waits until every transaction is included in a block and returns their responses in the order of the
+// hashes. All pending transactions are queried on every poll, so confirming a batch takes as long as its slowest
+// transaction. Transactions which failed in DeliverTx are returned with their non-zero code and no error.
+func (b *SequencedBroadcaster) Confirm(ctx context.Context, hashes ...string) ([]sdk.TxResponse, error) {
+	cc := b.node.CliContext().WithCodec(b.chain.Config.EncodingConfig.Codec)
+
+	responses := make([]sdk.TxResponse, len(hashes))
+	pending := make(map[int]string, len(hashes))
+	for i, hash := range hashes {
+		pending[i] = hash
+	}
+
+	err := wait.ForCondition(ctx, b.chain.getBlockWaitTimeout(), time.Second, func() (bool, error) {
+		for i, hash := range pending {
+			resp, err := authtx.QueryTx(cc, hash)
+			if err != nil {
+				if txNotFoundPattern.MatchString(err.Error()) {
+					continue
+				}
+				return false, fmt.Errorf("failed to query tx %s: %w", hash, err)
+			}
+			responses[i] = *resp
+			delete(pending, i)
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		return responses, fmt.Errorf("%d of %d transactions not included: %w", len(pending), len(hashes), err)
+	}
+	return responses, nil
+}
+
+// confirmOne waits for a single transaction to be included.
+func (b *SequencedBroadcaster) confirmOne(ctx context.Context, hash string) (sdk.TxResponse, error) {
+	responses, err := b.Confirm(ctx, hash)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return responses[0], nil
+}
+
+// submit signs the messages with the next sequence of the wallet, broadcasts the transaction, wrapped in a blob
+// transaction if there are blobs, and advances the sequence once the transaction was accepted.
+func (b *SequencedBroadcaster) submit(ctx context.Context, wallet *types.Wallet, blobs []*share.Blob, msgs ...sdk.Msg) (string, error) {
+	cc, err := b.clientContext(wallet)
+	if err != nil {
+		return "", err
+	}
+
+	seq := b.accountSequence(wallet)
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if !seq.synced {
+			if err := b.resync(cc, seq); err != nil {
+				return "", err
+			}
+		}
+
+		txBytes, err := b.sign(ctx, cc, wallet, seq, blobs, msgs)
+		if err != nil {
+			return "", err
+		}
+		if len(blobs) > 0 {
+			if txBytes, err = squaretx.MarshalBlobTx(txBytes, blobs...); err != nil {
+				return "", err
+			}
+		}
+
+		res, err := b.broadcast(cc, txBytes)
+		if err != nil {
+			// the transaction may or may not have reached the mempool.
+			seq.synced = false
+			return "", fmt.Errorf("failed to broadcast tx: %w", err)
+		}
+
+		if isSequenceMismatch(res) && attempt < maxSequenceRetries {
+			if expected, ok := expectedSequence(res.RawLog); ok {
+				seq.sequence = expected
+			} else {
+				seq.synced = false
+			}
+			b.chain.log.Debug("resyncing account sequence",
+				zap.String("wallet_address", wallet.GetFormattedAddress()),
+				zap.String("raw_log", res.RawLog))
+			continue
+		}
+
+		if res.Code != 0 {
+			return res.TxHash, fmt.Errorf("error in transaction (code: %d): raw_log: %s", res.Code, res.RawLog)
+		}
+
+		seq.sequence++
+		return res.TxHash, nil
+	}
+}
+
+// resync sets the account number and sequence of the wallet to those of its account on chain.
+func (b *SequencedBroadcaster) resync(cc client.Context, seq *accountSequence) error {
+	accountNumber, sequence, err := cc.AccountRetriever.GetAccountNumberSequence(cc, cc.GetFromAddress())
+	if err != nil {
+		return fmt.Errorf("failed to get account sequence: %w", err)
+	}
+	seq.accountNumber = accountNumber
+	seq.sequence = sequence
+	seq.synced = true
+	return nil
+}
+
+// sign builds and signs a transaction of the messages, paying for the blobs, with the current sequence of the wallet.
+func (b *SequencedBroadcaster) sign(ctx context.Context, cc client.Context, wallet *types.Wallet, seq *accountSequence, blobs []*share.Blob, msgs []sdk.Msg) ([]byte, error) {
+	txf, err := newTxFactory(b.chain.Config, cc, seq.accountNumber, seq.sequence)
+	if err != nil {
+		return nil, err
+	}
+	txf, err = b.node.configureGas(ctx, b.chain.Config, txf, blobs)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range b.factoryOptions {
+		txf = opt(txf)
+	}
+	txf = txf.WithAccountNumber(seq.accountNumber).WithSequence(seq.sequence)
+
+	if txf, err = simulateGas(cc, txf, msgs...); err != nil {
+		return nil, err
+	}
+
+	txBuilder, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tx: %w", err)
+	}
+	if err := sdktx.Sign(ctx, txf, wallet.GetKeyName(), txBuilder, true); err != nil {
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
+	}
+	return cc.TxConfig.TxEncoder()(txBuilder.GetTx())
+}
+
+// broadcast sends the transaction in the broadcast mode of the broadcaster.
+func (b *SequencedBroadcaster) broadcast(cc client.Context, txBytes []byte) (*sdk.TxResponse, error) {
+	if b.mode == BroadcastModeAsync {
+		return cc.BroadcastTxAsync(txBytes)
+	}
+	return cc.BroadcastTxSync(txBytes)
+}
+
+// accountSequence returns the locally tracked sequence of the wallet.
+func (b *SequencedBroadcaster) accountSequence(wallet *types.Wallet) *accountSequence {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	seq, ok := b.accounts[wallet.GetFormattedAddress()]
+	if !ok {
+		seq = &accountSequence{}
+		b.accounts[wallet.GetFormattedAddress()] = seq
+	}
+	return seq
+}
+
+// clientContext returns a client context configured with the wallet as the sender.
+func (b *SequencedBroadcaster) clientContext(wallet *types.Wallet) (client.Context, error) {
+	sdkAdd, err := sdkacc.AddressFromWallet(wallet)
+	if err != nil {
+		return client.Context{}, err
+	}
+
+	b.mu.Lock()
+	if b.keyring == nil {
+		containerKeyringDir := path.Join(b.node.HomeDir(), "keyring-test")
+		b.keyring = dockerinternal.NewDockerKeyring(b.node.DockerClient, b.node.ContainerLifecycle.ContainerID(), containerKeyringDir, b.node.EncodingConfig.Codec)
+	}
+	kr := b.keyring
+	b.mu.Unlock()
+
+	cc := b.node.CliContext().
+		WithFrom(wallet.GetFormattedAddress()).
+		WithFromAddress(sdkAdd).
+		WithFromName(wallet.GetKeyName()).
+		WithSkipConfirmation(true).
+		WithAccountRetriever(AccountRetriever{chain: b.chain, prefix: b.chain.Config.Bech32Prefix}).
+		WithKeyring(kr).
+		WithBroadcastMode(string(b.mode)).
+		WithCodec(b.chain.Config.EncodingConfig.Codec)
+	for _, opt := range b.clientContextOptions {
+		cc = opt(cc)
+	}
+	return cc, nil
+}
+
+// isSequenceMismatch reports whether the transaction was rejected because it was signed with the wrong sequence.
+func isSequenceMismatch(res *sdk.TxResponse) bool {
+	return res.Codespace == sdkerrors.ErrWrongSequence.Codespace() && res.Code == sdkerrors.ErrWrongSequence.ABCICode()
+}
+
+// expectedSequence returns the sequence expected by the node according to the log of a rejected transaction.
+func expectedSequence(rawLog string) (uint64, bool) {
+	matches := expectedSequencePattern.FindStringSubmatch(rawLog)
+	if len(matches) < 2 {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return seq, true
+}
@@ -0,0 +1,206 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// subscriptionCheckInterval is the interval at which the connection of a subscription is checked, and at
+	// which a lost subscription is retried.
+	subscriptionCheckInterval = 2 * time.Second
+	// subscriptionBufferSize is the number of events buffered by a subscription before CometBFT drops it.
+	subscriptionBufferSize = 100
+	// subscriberName identifies the subscriptions of a client, every subscription uses its own client.
+	subscriberName = "tastora"
+)
+
+// Subscribe subscribes to the events matching the CometBFT query, e.g. "tm.event='NewBlock'", and returns them
+// on the channel until ctx is done, at which point the channel is closed.
+// The subscription is re-established when the node becomes unreachable, e.g. when its container is restarted
+// by UpgradeVersion. Events emitted while the subscription is re-established are missed.
+func (cn *ChainNode) Subscribe(ctx context.Context, query string) (<-chan coretypes.ResultEvent, error) {
+	sub, err := cn.newSubscription(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan coretypes.ResultEvent)
+	go func() {
+		defer close(out)
+		defer func() {
+			if sub != nil {
+				sub.close()
+			}
+		}()
+
+		ticker := time.NewTicker(subscriptionCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-sub.events:
+				if !ok {
+					// CometBFT closes the subscription if the events are not consumed fast enough.
+					sub = cn.resubscribe(ctx, sub, query)
+					if sub == nil {
+						return
+					}
+					continue
+				}
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			case <-ticker.C:
+				if sub.alive(ctx, cn) {
+					continue
+				}
+				sub = cn.resubscribe(ctx, sub, query)
+				if sub == nil {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// SubscribeNewBlocks returns the blocks committed by the chain as they are received by the node, see Subscribe.
+func (cn *ChainNode) SubscribeNewBlocks(ctx context.Context) (<-chan cmttypes.EventDataNewBlock, error) {
+	events, err := cn.Subscribe(ctx, cmttypes.EventQueryNewBlock.String())
+	if err != nil {
+		return nil, err
+	}
+	return eventData[cmttypes.EventDataNewBlock](ctx, events), nil
+}
+
+// SubscribeTxs returns the transactions matching the CometBFT query as they are committed, all transactions if
+// the query is empty, see Subscribe. events.TxQuery builds queries matching transactions by their events.
+func (cn *ChainNode) SubscribeTxs(ctx context.Context, query string) (<-chan cmttypes.EventDataTx, error) {
+	if query == "" {
+		query = cmttypes.EventQueryTx.String()
+	}
+	events, err := cn.Subscribe(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return eventData[cmttypes.EventDataTx](ctx, events), nil
+}
+
+// SubscribeHeights returns the heights of the blocks committed by the chain, see SubscribeNewBlocks.
+func (cn *ChainNode) SubscribeHeights(ctx context.Context) (<-chan int64, error) {
+	blocks, err := cn.SubscribeNewBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make(chan int64)
+	go func() {
+		defer close(heights)
+		for b := range blocks {
+			select {
+			case heights <- b.Block.Height:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return heights, nil
+}
+
+// SubscribeHeights returns the heights of the blocks committed by the chain, as received by its first node.
+func (c *Chain) SubscribeHeights(ctx context.Context) (<-chan int64, error) {
+	return c.GetNode().SubscribeHeights(ctx)
+}
+
+// eventData returns the data of the events of type T.
+func eventData[T any](ctx context.Context, events <-chan coretypes.ResultEvent) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for ev := range events {
+			data, ok := ev.Data.(T)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// subscription is a subscription over a websocket connection to the node's RPC.
+type subscription struct {
+	// address is the RPC address the subscription is connected to, the host port of the node changes when its
+	// container is recreated.
+	address string
+	client  *rpchttp.HTTP
+	events  <-chan coretypes.ResultEvent
+}
+
+// newSubscription connects to the node's RPC and subscribes to the query.
+func (cn *ChainNode) newSubscription(ctx context.Context, query string) (*subscription, error) {
+	address := cn.hostRPCAddress()
+	client, err := rpchttp.New(address, "/websocket")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client: %w", err)
+	}
+	if err := client.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start rpc client: %w", err)
+	}
+
+	events, err := client.Subscribe(ctx, subscriberName, query, subscriptionBufferSize)
+	if err != nil {
+		_ = client.Stop()
+		return nil, fmt.Errorf("failed to subscribe to %q: %w", query, err)
+	}
+	return &subscription{address: address, client: client, events: events}, nil
+}
+
+// resubscribe closes the subscription and subscribes to the query again, retrying until ctx is done in which
+// case nil is returned.
+func (cn *ChainNode) resubscribe(ctx context.Context, old *subscription, query string) *subscription {
+	old.close()
+	for {
+		sub, err := cn.newSubscription(ctx, query)
+		if err == nil {
+			cn.logger().Info("resubscribed to node events", zap.String("query", query))
+			return sub
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(subscriptionCheckInterval):
+		}
+	}
+}
+
+// alive returns true if the node is still reachable at the address the subscription is connected to.
+func (s *subscription) alive(ctx context.Context, cn *ChainNode) bool {
+	if s.address != cn.hostRPCAddress() {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, subscriptionCheckInterval)
+	defer cancel()
+	_, err := s.client.Health(ctx)
+	return err == nil
+}
+
+// close stops the client of the subscription, which ends the subscription.
+func (s *subscription) close() {
+	_ = s.client.Stop()
+}
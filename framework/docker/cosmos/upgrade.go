@@ -0,0 +1,199 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"go.uber.org/zap"
+)
+
+// defaultUpgradeHeightDelta is the number of blocks after the current height at which an upgrade is
+// scheduled when no explicit height is provided. It must leave enough room for the 30s voting period
+// configured in genesis to elapse before the plan height is reached.
+const defaultUpgradeHeightDelta = 40
+
+// SoftwareUpgrade describes a chain upgrade performed through an x/upgrade plan.
+type SoftwareUpgrade struct {
+	// Name is the name of the upgrade plan. It must match an upgrade handler registered by the new version.
+	Name string
+	// Version is the image version all nodes are switched to once the chain halts at the plan height.
+	Version string
+	// Height is the height at which the upgrade is applied. If zero, the upgrade is scheduled
+	// HeightDelta blocks after the current height.
+	Height int64
+	// HeightDelta is the number of blocks after the current height to schedule the upgrade at when
+	// Height is not set. Defaults to 40.
+	HeightDelta int64
+	// Info is optional metadata attached to the upgrade plan.
+	Info string
+}
+
+// SoftwareUpgrade upgrades the chain the same way a live network is upgraded.
+// A MsgSoftwareUpgrade proposal is submitted and voted on by all validators, the chain is left to halt at
+// the plan height, every node is switched to the new image version while preserving its volumes, and
+// the chain is restarted. It returns once blocks are produced past the upgrade height.
+func (c *Chain) SoftwareUpgrade(ctx context.Context, upgrade SoftwareUpgrade) error {
+	if upgrade.Name == "" {
+		return fmt.Errorf("upgrade name must be specified")
+	}
+	if upgrade.Version == "" {
+		return fmt.Errorf("upgrade version must be specified")
+	}
+
+	currentHeight, err := c.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current height: %w", err)
+	}
+
+	upgradeHeight := upgrade.Height
+	if upgradeHeight == 0 {
+		delta := upgrade.HeightDelta
+		if delta == 0 {
+			delta = defaultUpgradeHeightDelta
+		}
+		upgradeHeight = currentHeight + delta
+	}
+	if upgradeHeight <= currentHeight {
+		return fmt.Errorf("upgrade height %d must be greater than the current height %d", upgradeHeight, currentHeight)
+	}
+
+	if err := c.submitSoftwareUpgradeProposal(ctx, upgrade, upgradeHeight); err != nil {
+		return err
+	}
+
+	c.log.Info("software upgrade scheduled",
+		zap.String("name", upgrade.Name),
+		zap.String("version", upgrade.Version),
+		zap.Int64("height", upgradeHeight),
+	)
+
+	if err := c.waitForUpgradeHalt(ctx, upgradeHeight); err != nil {
+		return err
+	}
+
+	// remove containers but preserve volumes so that the new version runs the store migrations
+	// against the existing state.
+	if err := c.Remove(ctx, types.WithPreserveVolumes()); err != nil {
+		return fmt.Errorf("failed to remove containers for upgrade: %w", err)
+	}
+
+	c.Config.Image.Version = upgrade.Version
+	for _, n := range c.Nodes() {
+		n.Image.Version = upgrade.Version
+	}
+
+	c.pullImages(ctx)
+
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start chain after upgrade: %w", err)
+	}
+
+	err = wait.ForCondition(ctx, c.getBlockWaitTimeout(), time.Second, func() (bool, error) {
+		height, err := c.Height(ctx)
+		if err != nil {
+			// the node may still be running migrations, keep waiting
+			return false, nil
+		}
+		return height > upgradeHeight, nil
+	})
+	if err != nil {
+		return fmt.Errorf("chain did not produce blocks past upgrade height %d: %w", upgradeHeight, err)
+	}
+
+	return nil
+}
+
+// submitSoftwareUpgradeProposal submits a MsgSoftwareUpgrade governance proposal and ensures that it passes.
+func (c *Chain) submitSoftwareUpgradeProposal(ctx context.Context, upgrade SoftwareUpgrade, upgradeHeight int64) error {
+	msg := &upgradetypes.MsgSoftwareUpgrade{
+		Authority: authtypes.NewModuleAddress("gov").String(),
+		Plan: upgradetypes.Plan{
+			Name:   upgrade.Name,
+			Height: upgradeHeight,
+			Info:   upgrade.Info,
+		},
+	}
+
+	anyMsg, err := codectypes.NewAnyWithValue(msg)
+	if err != nil {
+		return fmt.Errorf("failed to pack software upgrade message: %w", err)
+	}
+
+	proposal := &govv1.MsgSubmitProposal{
+		Messages:       []*codectypes.Any{anyMsg},
+		InitialDeposit: sdk.NewCoins(sdk.NewCoin(c.Config.Denom, sdkmath.NewInt(1000))),
+		Proposer:       c.GetFaucetWallet().GetFormattedAddress(),
+		Title:          fmt.Sprintf("Software upgrade %s", upgrade.Name),
+		Summary:        fmt.Sprintf("Upgrade to %s at height %d", upgrade.Version, upgradeHeight),
+	}
+
+	prop, err := c.SubmitAndVoteOnGovV1Proposal(ctx, proposal, govv1.VoteOption_VOTE_OPTION_YES)
+	if err != nil {
+		return fmt.Errorf("failed to submit software upgrade proposal: %w", err)
+	}
+
+	if prop.Status != govv1.ProposalStatus_PROPOSAL_STATUS_PASSED {
+		return fmt.Errorf("software upgrade proposal %d did not pass: %s", prop.Id, prop.Status)
+	}
+
+	return nil
+}
+
+// waitForUpgradeHalt waits for the chain to reach the block before the upgrade height and then
+// ensures that it stops producing blocks, as the running version refuses to execute the plan height.
+func (c *Chain) waitForUpgradeHalt(ctx context.Context, upgradeHeight int64) error {
+	// wait for at least one block per expected block time, with some leeway.
+	currentHeight, err := c.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current height: %w", err)
+	}
+	timeout := time.Duration(upgradeHeight-currentHeight)*time.Duration(blockTime)*time.Second*2 + c.getBlockWaitTimeout()
+
+	err = wait.ForCondition(ctx, timeout, time.Second, func() (bool, error) {
+		height, err := c.Height(ctx)
+		if err != nil {
+			// the node may have exited after reaching the upgrade height
+			return false, nil
+		}
+		return height >= upgradeHeight-1, nil
+	})
+	if err != nil {
+		return fmt.Errorf("chain did not reach upgrade height %d: %w", upgradeHeight, err)
+	}
+
+	// the chain is considered halted once the height has stopped increasing for several block times.
+	var lastHeight int64
+	stalledPolls := 0
+	err = wait.ForCondition(ctx, c.getBlockWaitTimeout(), time.Duration(blockTime)*time.Second, func() (bool, error) {
+		height, err := c.Height(ctx)
+		if err != nil {
+			// a node which has crashed on the upgrade height will not respond.
+			return true, nil
+		}
+		if height > upgradeHeight {
+			return false, fmt.Errorf("chain continued past upgrade height %d, now at height %d", upgradeHeight, height)
+		}
+		if height == lastHeight {
+			stalledPolls++
+		} else {
+			stalledPolls = 0
+			lastHeight = height
+		}
+		return stalledPolls >= 3, nil
+	})
+	if err != nil {
+		return fmt.Errorf("chain did not halt at upgrade height %d: %w", upgradeHeight, err)
+	}
+
+	c.log.Info("chain halted for upgrade", zap.Int64("height", lastHeight))
+	return nil
+}
@@ -0,0 +1,352 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/testutil/maps"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	tmjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/privval"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// promotionFeeBuffer is the amount, in addition to the self delegation, a promoted validator's operator
+// account is funded with to pay for the fees of its transactions.
+const promotionFeeBuffer = 1_000_000
+
+// FastDowntimeJailing returns genesis modifications, to be used with ChainBuilder.WithGenesisModifications,
+// which jail a validator once it misses more than half of a 10 block window and allow it to unjail after 10s.
+// The default parameters require thousands of missed blocks, which is impractical within a test.
+func FastDowntimeJailing() []maps.Entry {
+	return []maps.Entry{
+		{Path: "app_state.slashing.params.signed_blocks_window", Value: "10"},
+		{Path: "app_state.slashing.params.min_signed_per_window", Value: "0.500000000000000000"},
+		{Path: "app_state.slashing.params.downtime_jail_duration", Value: "10s"},
+	}
+}
+
+// PromoteToValidator turns a running full node of the chain into a bonded validator.
+// An operator key is created in the node's keyring, funded from the faucet with the self delegation, and a
+// MsgCreateValidator using the node's consensus key is broadcast through the node. Once the validator is
+// bonded the node is moved from the chain's full nodes to its validators and its operator wallet is returned.
+func (c *Chain) PromoteToValidator(ctx context.Context, node *ChainNode, selfDelegation sdk.Coin) (*types.Wallet, error) {
+	if !c.isFullNode(node) {
+		return nil, fmt.Errorf("node %s is not a full node of chain %s", node.Name(), c.GetChainID())
+	}
+
+	operator, err := node.CreateWallet(ctx, valKey, c.Config.Bech32Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create operator key: %w", err)
+	}
+
+	faucet := c.GetFaucetWallet()
+	if faucet == nil {
+		return nil, fmt.Errorf("faucet wallet not initialized")
+	}
+	fromAddr, err := sdkacc.AddressFromWallet(faucet)
+	if err != nil {
+		return nil, err
+	}
+	funds := sdk.NewCoins(selfDelegation.AddAmount(sdkmath.NewInt(promotionFeeBuffer)))
+	if _, err := c.BroadcastMessages(ctx, faucet, banktypes.NewMsgSend(fromAddr, sdk.AccAddress(operator.Address), funds)); err != nil {
+		return nil, fmt.Errorf("failed to fund operator account: %w", err)
+	}
+
+	pvKey, err := node.privValidatorKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := cryptocodec.FromCmtPubKeyInterface(pvKey.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert consensus public key: %w", err)
+	}
+
+	commission := stakingtypes.NewCommissionRates(
+		sdkmath.LegacyMustNewDecFromStr("0.1"),
+		sdkmath.LegacyMustNewDecFromStr("0.2"),
+		sdkmath.LegacyMustNewDecFromStr("0.01"),
+	)
+	msg, err := stakingtypes.NewMsgCreateValidator(
+		c.valoperAddress(operator.Address),
+		pubKey,
+		selfDelegation,
+		stakingtypes.NewDescription(CondenseMoniker(node.Name()), "", "", "", ""),
+		commission,
+		sdkmath.OneInt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MsgCreateValidator: %w", err)
+	}
+
+	if _, err := node.GetBroadcaster(c).BroadcastMessages(ctx, operator, msg); err != nil {
+		return nil, fmt.Errorf("failed to broadcast MsgCreateValidator: %w", err)
+	}
+
+	if err := c.WaitForValidator(ctx, node, func(v stakingtypes.Validator) bool {
+		return v.IsBonded()
+	}); err != nil {
+		return nil, fmt.Errorf("validator %s was not bonded: %w", node.Name(), err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, n := range c.FullNodes {
+		if n == node {
+			c.FullNodes = append(c.FullNodes[:i], c.FullNodes[i+1:]...)
+			break
+		}
+	}
+	node.Validator = true
+	c.Validators = append(c.Validators, node)
+
+	c.log.Info("promoted full node to validator", zap.String("node", node.Name()), zap.String("operator", operator.GetFormattedAddress()))
+	return operator, nil
+}
+
+// OperatorWallet returns the wallet of the operator key held in the keyring of a validator node.
+func (c *Chain) OperatorWallet(ctx context.Context, node *ChainNode) (*types.Wallet, error) {
+	addr, err := node.getAddress(ctx, valKey, c.Config.Bech32Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator address of %s: %w", node.Name(), err)
+	}
+	return types.NewWallet(addr, sdk.MustBech32ifyAddressBytes(c.Config.Bech32Prefix, addr), c.Config.Bech32Prefix, valKey), nil
+}
+
+// ValidatorAddress returns the bech32 operator address of a validator node.
+func (c *Chain) ValidatorAddress(ctx context.Context, node *ChainNode) (string, error) {
+	operator, err := c.OperatorWallet(ctx, node)
+	if err != nil {
+		return "", err
+	}
+	return c.valoperAddress(operator.Address), nil
+}
+
+// ConsensusAddress returns the bech32 consensus address of a node, derived from its priv_validator_key.json.
+func (c *Chain) ConsensusAddress(ctx context.Context, node *ChainNode) (string, error) {
+	pvKey, err := node.privValidatorKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return sdk.MustBech32ifyAddressBytes(c.Config.Bech32Prefix+sdk.PrefixValidator+sdk.PrefixConsensus, pvKey.Address), nil
+}
+
+// Delegate delegates the amount from the delegator to a validator node.
+func (c *Chain) Delegate(ctx context.Context, delegator *types.Wallet, validator *ChainNode, amount sdk.Coin) (sdk.TxResponse, error) {
+	valAddr, err := c.ValidatorAddress(ctx, validator)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return c.broadcastFrom(ctx, delegator, stakingtypes.NewMsgDelegate(delegator.GetFormattedAddress(), valAddr, amount))
+}
+
+// Undelegate starts unbonding the amount the delegator delegated to a validator node.
+// Unbonding the whole self delegation of a validator removes it from the active set.
+func (c *Chain) Undelegate(ctx context.Context, delegator *types.Wallet, validator *ChainNode, amount sdk.Coin) (sdk.TxResponse, error) {
+	valAddr, err := c.ValidatorAddress(ctx, validator)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return c.broadcastFrom(ctx, delegator, stakingtypes.NewMsgUndelegate(delegator.GetFormattedAddress(), valAddr, amount))
+}
+
+// Redelegate moves the amount the delegator delegated to the source validator node to the destination validator node.
+func (c *Chain) Redelegate(ctx context.Context, delegator *types.Wallet, src, dst *ChainNode, amount sdk.Coin) (sdk.TxResponse, error) {
+	srcAddr, err := c.ValidatorAddress(ctx, src)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	dstAddr, err := c.ValidatorAddress(ctx, dst)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return c.broadcastFrom(ctx, delegator, stakingtypes.NewMsgBeginRedelegate(delegator.GetFormattedAddress(), srcAddr, dstAddr, amount))
+}
+
+// JailValidator forces a validator node to miss blocks by stopping its container until it is jailed for downtime,
+// then restarts it and waits for it to catch up with the chain. The remaining validators must hold more than
+// two thirds of the voting power for blocks to be produced while the node is down, and the slashing
+// parameters should be lowered with FastDowntimeJailing for the validator to be jailed within the block wait timeout.
+func (c *Chain) JailValidator(ctx context.Context, node *ChainNode) error {
+	if !c.isValidator(node) {
+		return fmt.Errorf("node %s is not a validator of chain %s", node.Name(), c.GetChainID())
+	}
+	reference := c.referenceNode(node)
+	if reference == nil {
+		return fmt.Errorf("chain %s has no other node to produce blocks while %s is down", c.GetChainID(), node.Name())
+	}
+
+	if err := node.StopContainer(ctx); err != nil {
+		return fmt.Errorf("failed to stop node %s: %w", node.Name(), err)
+	}
+
+	jailErr := c.WaitForValidator(ctx, node, func(v stakingtypes.Validator) bool {
+		return v.IsJailed()
+	})
+
+	// the node is restarted even if it was not jailed so that the chain is left as it was found.
+	c.mu.Lock()
+	err := node.startContainer(ctx)
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to restart node %s: %w", node.Name(), err)
+	}
+	if jailErr != nil {
+		return fmt.Errorf("validator %s was not jailed: %w", node.Name(), jailErr)
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, c.getBlockWaitTimeout())
+	defer cancel()
+	if err := wait.ForInSync(syncCtx, reference, node); err != nil {
+		return fmt.Errorf("node %s did not sync with %s after downtime: %w", node.Name(), reference.Name(), err)
+	}
+
+	c.log.Info("validator jailed for downtime", zap.String("node", node.Name()))
+	return nil
+}
+
+// Unjail broadcasts a MsgUnjail for a jailed validator node, signed by its operator key, and waits until
+// the validator is no longer jailed. The downtime jail duration must have elapsed.
+func (c *Chain) Unjail(ctx context.Context, node *ChainNode) error {
+	operator, err := c.OperatorWallet(ctx, node)
+	if err != nil {
+		return err
+	}
+	if _, err := node.GetBroadcaster(c).BroadcastMessages(ctx, operator, slashingtypes.NewMsgUnjail(c.valoperAddress(operator.Address))); err != nil {
+		return fmt.Errorf("failed to broadcast MsgUnjail: %w", err)
+	}
+	return c.WaitForValidator(ctx, node, func(v stakingtypes.Validator) bool {
+		return !v.IsJailed()
+	})
+}
+
+// QueryValidator returns the staking state of a validator node.
+func (c *Chain) QueryValidator(ctx context.Context, node *ChainNode) (stakingtypes.Validator, error) {
+	valAddr, err := c.ValidatorAddress(ctx, node)
+	if err != nil {
+		return stakingtypes.Validator{}, err
+	}
+	return c.queryValidator(ctx, node, valAddr)
+}
+
+// queryValidator returns the staking state of the validator with the given operator address, querying a node
+// other than the validator node itself.
+func (c *Chain) queryValidator(ctx context.Context, node *ChainNode, valAddr string) (stakingtypes.Validator, error) {
+	res, err := stakingtypes.NewQueryClient(c.queryConn(node)).Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valAddr})
+	if err != nil {
+		return stakingtypes.Validator{}, fmt.Errorf("failed to query validator %s: %w", valAddr, err)
+	}
+	return res.Validator, nil
+}
+
+// QuerySigningInfo returns the slashing signing info of a validator node, which records missed blocks and jailing.
+func (c *Chain) QuerySigningInfo(ctx context.Context, node *ChainNode) (slashingtypes.ValidatorSigningInfo, error) {
+	consAddr, err := c.ConsensusAddress(ctx, node)
+	if err != nil {
+		return slashingtypes.ValidatorSigningInfo{}, err
+	}
+	res, err := slashingtypes.NewQueryClient(c.queryConn(node)).SigningInfo(ctx, &slashingtypes.QuerySigningInfoRequest{ConsAddress: consAddr})
+	if err != nil {
+		return slashingtypes.ValidatorSigningInfo{}, fmt.Errorf("failed to query signing info of %s: %w", consAddr, err)
+	}
+	return res.ValSigningInfo, nil
+}
+
+// QueryDelegation returns the delegation of the delegator to a validator node.
+func (c *Chain) QueryDelegation(ctx context.Context, delegator *types.Wallet, validator *ChainNode) (stakingtypes.DelegationResponse, error) {
+	valAddr, err := c.ValidatorAddress(ctx, validator)
+	if err != nil {
+		return stakingtypes.DelegationResponse{}, err
+	}
+	res, err := stakingtypes.NewQueryClient(c.queryConn(validator)).Delegation(ctx, &stakingtypes.QueryDelegationRequest{
+		DelegatorAddr: delegator.GetFormattedAddress(),
+		ValidatorAddr: valAddr,
+	})
+	if err != nil {
+		return stakingtypes.DelegationResponse{}, fmt.Errorf("failed to query delegation to %s: %w", valAddr, err)
+	}
+	return *res.DelegationResponse, nil
+}
+
+// WaitForValidator waits until the staking state of a validator node satisfies the condition, e.g. until it is bonded or jailed.
+func (c *Chain) WaitForValidator(ctx context.Context, node *ChainNode, condition func(stakingtypes.Validator) bool) error {
+	// the address is resolved once as it is read from the node's keyring with a one-shot container.
+	valAddr, err := c.ValidatorAddress(ctx, node)
+	if err != nil {
+		return err
+	}
+	return wait.ForCondition(ctx, c.getBlockWaitTimeout(), time.Second, func() (bool, error) {
+		v, err := c.queryValidator(ctx, node, valAddr)
+		if err != nil {
+			// the validator does not exist until the MsgCreateValidator is committed.
+			return false, nil
+		}
+		return condition(v), nil
+	})
+}
+
+// broadcastFrom broadcasts the messages signed by the wallet through the first node whose keyring holds its key.
+func (c *Chain) broadcastFrom(ctx context.Context, wallet *types.Wallet, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	n, _, err := c.nodeWithKey(wallet)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return n.GetBroadcaster(c).BroadcastMessages(ctx, wallet, msgs...)
+}
+
+// queryConn returns the gRPC connection of a node other than the excluded one, which may be down, falling back to the
+// excluded node if it is the only node of the chain.
+func (c *Chain) queryConn(excluded *ChainNode) *grpc.ClientConn {
+	if n := c.referenceNode(excluded); n != nil {
+		return n.GrpcConn
+	}
+	return excluded.GrpcConn
+}
+
+// valoperAddress returns the bech32 operator address for the account address bytes.
+func (c *Chain) valoperAddress(addr []byte) string {
+	return sdk.MustBech32ifyAddressBytes(c.Config.Bech32Prefix+sdk.PrefixValidator+sdk.PrefixOperator, addr)
+}
+
+// isValidator returns true if the node is one of the chain's validators.
+func (c *Chain) isValidator(node *ChainNode) bool {
+	for _, n := range c.Validators {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+// isFullNode returns true if the node is one of the chain's full nodes.
+func (c *Chain) isFullNode(node *ChainNode) bool {
+	for _, n := range c.FullNodes {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+// privValidatorKey returns the parsed priv_validator_key.json of the node.
+func (cn *ChainNode) privValidatorKey(ctx context.Context) (privval.FilePVKey, error) {
+	bz, err := cn.ReadPrivValidatorKey(ctx)
+	if err != nil {
+		return privval.FilePVKey{}, err
+	}
+	var pvKey privval.FilePVKey
+	if err := tmjson.Unmarshal(bz, &pvKey); err != nil {
+		return privval.FilePVKey{}, fmt.Errorf("failed to parse priv_validator_key.json: %w", err)
+	}
+	return pvKey, nil
+}
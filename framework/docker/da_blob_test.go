@@ -0,0 +1,75 @@
+package docker
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/go-square/v3/share"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDASubmitAndVerifyBlobs submits blobs through a bridge node with its own wallet and verifies that
+// both the bridge and the light node serve them with valid inclusion proofs.
+func TestDASubmitAndVerifyBlobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	daNetwork, err := testCfg.DANetworkBuilder.
+		WithChainID(chain.GetChainID()).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+	startDANodes(t, testCfg, chain, daNetwork)
+	require.NotEmpty(t, daNetwork.GetNodesByType(types.LightNode), "blobs should be verified against a light node")
+
+	bridgeNode := daNetwork.GetBridgeNodes()[0]
+	daWallet, err := bridgeNode.GetWallet()
+	require.NoError(t, err)
+
+	fromAddr, err := sdkacc.AddressFromWallet(chain.GetFaucetWallet())
+	require.NoError(t, err)
+	toAddr, err := sdk.AccAddressFromBech32(daWallet.GetFormattedAddress())
+	require.NoError(t, err)
+	send := banktypes.NewMsgSend(fromAddr, toAddr, sdk.NewCoins(sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(100_000_000))))
+	_, err = chain.BroadcastMessages(testCfg.Ctx, chain.GetFaucetWallet(), send)
+	require.NoError(t, err)
+
+	ns := share.MustNewV0Namespace([]byte("tastora"))
+	blobA, err := share.NewV0Blob(ns, []byte("first blob"))
+	require.NoError(t, err)
+	blobB, err := share.NewV0Blob(ns, []byte("second blob"))
+	require.NoError(t, err)
+
+	height, err := bridgeNode.SubmitBlobs(testCfg.Ctx, []*share.Blob{blobA, blobB}, nil)
+	require.NoError(t, err)
+	require.NotZero(t, height)
+
+	// wait for the light node to sync the header the blobs were included in.
+	for _, lightNode := range daNetwork.GetLightNodes() {
+		_, err := lightNode.RPCClient().Header.WaitForHeight(testCfg.Ctx, height)
+		require.NoError(t, err)
+	}
+
+	for _, blob := range []*share.Blob{blobA, blobB} {
+		report, err := daNetwork.VerifyBlobInclusion(testCfg.Ctx, height, blob)
+		require.NoError(t, err)
+		require.NoError(t, report.Err())
+		require.Len(t, report.ServedBy(), len(daNetwork.GetNodes()))
+	}
+
+	blobs, err := bridgeNode.GetAllBlobs(testCfg.Ctx, height, []share.Namespace{ns})
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+}
@@ -0,0 +1,149 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	da "github.com/celestiaorg/tastora/framework/docker/dataavailability"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDARPCClient verifies the typed JSON-RPC client against a running bridge node.
+func TestDARPCClient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	daNetwork, err := testCfg.DANetworkBuilder.
+		WithChainID(chain.GetChainID()).
+		WithNodes(da.NewNodeBuilder().WithNodeType(types.BridgeNode).Build()).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+
+	startDANodes(t, testCfg, chain, daNetwork)
+
+	chainID := chain.GetChainID()
+	bridgeNode := daNetwork.GetBridgeNodes()[0]
+	client := bridgeNode.RPCClient()
+
+	t.Run("header", func(t *testing.T) {
+		header, err := client.Header.WaitForHeight(testCfg.Ctx, 2)
+		require.NoError(t, err)
+		require.Equal(t, chainID, header.Header.ChainID)
+		require.NotEmpty(t, header.DAH.RowRoots)
+		require.NotEmpty(t, header.Commit.Signatures)
+
+		head, err := client.Header.LocalHead(testCfg.Ctx)
+		require.NoError(t, err)
+		height, err := head.Height()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, height, uint64(2))
+
+		_, err = client.Header.SyncState(testCfg.Ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("share", func(t *testing.T) {
+		header, err := client.Header.GetByHeight(testCfg.Ctx, 2)
+		require.NoError(t, err)
+
+		eds, err := client.Share.GetEDS(testCfg.Ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, eds.DataSquare, header.DAH.SquareWidth()*header.DAH.SquareWidth())
+
+		samples, err := client.Share.GetSamples(testCfg.Ctx, header, []da.SampleCoords{{Row: 0, Col: 0}})
+		require.NoError(t, err)
+		require.Len(t, samples, 1)
+	})
+
+	t.Run("state", func(t *testing.T) {
+		wallet, err := bridgeNode.GetWallet()
+		require.NoError(t, err)
+
+		addr, err := client.State.AccountAddress(testCfg.Ctx)
+		require.NoError(t, err)
+		require.Equal(t, wallet.GetFormattedAddress(), addr)
+
+		_, err = client.State.Balance(testCfg.Ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("subscribe", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, time.Minute)
+		defer cancel()
+
+		head, err := wait.ForNewDAHeaders(ctx, bridgeNode, 2)
+		require.NoError(t, err)
+		require.NoError(t, wait.ForDAHeight(ctx, bridgeNode, head.Height))
+
+		height, err := wait.ForDANodesInSync(ctx, bridgeNode)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, height, head.Height)
+	})
+
+	t.Run("p2p", func(t *testing.T) {
+		info, err := client.P2P.Info(testCfg.Ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, info.PeerID)
+
+		_, err = client.P2P.Peers(testCfg.Ctx)
+		require.NoError(t, err)
+	})
+}
+
+// startDANodes starts the bridge nodes of the network connected to the chain, followed by the light nodes
+// connected to the first bridge node.
+func startDANodes(t *testing.T, testCfg *TestSetupConfig, chain *cosmos.Chain, daNetwork *da.Network) {
+	t.Helper()
+
+	genesisHash, err := getGenesisHash(testCfg.Ctx, chain)
+	require.NoError(t, err)
+
+	chainNetworkInfo, err := chain.GetNodes()[0].GetNetworkInfo(testCfg.Ctx)
+	require.NoError(t, err)
+
+	chainID := chain.GetChainID()
+	for _, bridgeNode := range daNetwork.GetBridgeNodes() {
+		require.NoError(t, bridgeNode.Start(testCfg.Ctx,
+			da.WithChainID(chainID),
+			da.WithAdditionalStartArguments("--p2p.network", chainID, "--core.ip", chainNetworkInfo.Internal.Hostname, "--rpc.addr", "0.0.0.0"),
+			da.WithEnvironmentVariables(map[string]string{
+				"CELESTIA_CUSTOM": types.BuildCelestiaCustomEnvVar(chainID, genesisHash, ""),
+				"P2P_NETWORK":     chainID,
+			}),
+		))
+	}
+
+	lightNodes := daNetwork.GetLightNodes()
+	if len(lightNodes) == 0 {
+		return
+	}
+
+	p2pInfo, err := daNetwork.GetBridgeNodes()[0].GetP2PInfo(testCfg.Ctx)
+	require.NoError(t, err)
+	p2pAddr, err := p2pInfo.GetP2PAddress()
+	require.NoError(t, err)
+
+	for _, lightNode := range lightNodes {
+		require.NoError(t, lightNode.Start(testCfg.Ctx,
+			da.WithChainID(chainID),
+			da.WithAdditionalStartArguments("--p2p.network", chainID, "--rpc.addr", "0.0.0.0"),
+			da.WithEnvironmentVariables(map[string]string{
+				"CELESTIA_CUSTOM": types.BuildCelestiaCustomEnvVar(chainID, genesisHash, p2pAddr),
+				"P2P_NETWORK":     chainID,
+			}),
+		))
+	}
+}
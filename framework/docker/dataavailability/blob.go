@@ -0,0 +1,193 @@
+package dataavailability
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/celestiaorg/go-square/v3/inclusion"
+	"github.com/celestiaorg/go-square/v3/share"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+)
+
+// subtreeRootThreshold is the subtree root threshold of celestia-app used to compute share commitments.
+const subtreeRootThreshold = 64
+
+// BlobCommitment computes the share commitment of the blob, which identifies it at a given height.
+func BlobCommitment(blob *share.Blob) ([]byte, error) {
+	commitment, err := inclusion.CreateCommitment(blob, merkle.HashFromByteSlices, subtreeRootThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob commitment: %w", err)
+	}
+	return commitment, nil
+}
+
+// NewRPCBlob converts the blob to the encoding used by the JSON-RPC API, including its share commitment.
+func NewRPCBlob(blob *share.Blob) (types.Blob, error) {
+	commitment, err := BlobCommitment(blob)
+	if err != nil {
+		return types.Blob{}, err
+	}
+	rpcBlob := types.Blob{
+		Namespace:    base64.StdEncoding.EncodeToString(blob.Namespace().Bytes()),
+		Data:         base64.StdEncoding.EncodeToString(blob.Data()),
+		ShareVersion: int(blob.ShareVersion()),
+		Commitment:   base64.StdEncoding.EncodeToString(commitment),
+	}
+	if blob.HasSigner() {
+		rpcBlob.Signer = base64.StdEncoding.EncodeToString(blob.Signer())
+	}
+	return rpcBlob, nil
+}
+
+// SubmitBlobs submits the blobs in a single PayForBlobs transaction through the node's blob.Submit and returns
+// the height they were included at. Unless the options set a key name or signer address, the transaction
+// is signed with the node's own wallet, which must have been funded. A nil options uses the node's defaults.
+func (n *Node) SubmitBlobs(ctx context.Context, blobs []*share.Blob, opts *TxConfig) (uint64, error) {
+	wallet, err := n.GetWallet()
+	if err != nil {
+		return 0, err
+	}
+
+	var cfg TxConfig
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.KeyName == "" && cfg.SignerAddress == "" {
+		cfg.KeyName = wallet.GetKeyName()
+	}
+
+	rpcBlobs := make([]types.Blob, len(blobs))
+	for i, blob := range blobs {
+		if rpcBlobs[i], err = NewRPCBlob(blob); err != nil {
+			return 0, err
+		}
+	}
+
+	height, err := n.RPCClient().Blob.Submit(ctx, rpcBlobs, &cfg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to submit blobs through %s: %w", n.Name(), err)
+	}
+	return height, nil
+}
+
+// BlobInclusionResult is the outcome of verifying a blob against a single DA node.
+type BlobInclusionResult struct {
+	Node     string
+	NodeType types.DANodeType
+	// Err is nil if the node served the blob with the expected data and commitment and proved its inclusion.
+	Err error
+}
+
+// BlobInclusionReport reports which DA nodes can serve a blob and prove its inclusion.
+type BlobInclusionReport struct {
+	Height     uint64
+	Commitment []byte
+	Results    []BlobInclusionResult
+}
+
+// ServedBy returns the names of the nodes which served the blob and proved its inclusion.
+func (r BlobInclusionReport) ServedBy() []string {
+	var names []string
+	for _, res := range r.Results {
+		if res.Err == nil {
+			names = append(names, res.Node)
+		}
+	}
+	return names
+}
+
+// Err returns the errors of every node which could not serve the blob, or nil if all nodes served it.
+func (r BlobInclusionReport) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("%s node %s: %w", res.NodeType, res.Node, res.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// VerifyBlobInclusion fetches the blob included at the given height back from every node, checks that the
+// data and share commitment match, and checks its inclusion proof against the data root with blob.Included.
+// The nodes are queried concurrently. An error is only returned if the commitment of the blob can't be
+// computed, failures of individual nodes are reported in the results.
+func VerifyBlobInclusion(ctx context.Context, height uint64, blob *share.Blob, nodes ...*Node) (BlobInclusionReport, error) {
+	commitment, err := BlobCommitment(blob)
+	if err != nil {
+		return BlobInclusionReport{}, err
+	}
+
+	report := BlobInclusionReport{
+		Height:     height,
+		Commitment: commitment,
+		Results:    make([]BlobInclusionResult, len(nodes)),
+	}
+
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Results[i] = BlobInclusionResult{
+				Node:     node.Name(),
+				NodeType: node.GetType(),
+				Err:      verifyBlobInclusion(ctx, node.RPCClient(), height, blob, commitment),
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report, nil
+}
+
+// VerifyBlobInclusion verifies the blob included at the given height against every node of the network.
+// See VerifyBlobInclusion.
+func (n *Network) VerifyBlobInclusion(ctx context.Context, height uint64, blob *share.Blob) (BlobInclusionReport, error) {
+	return VerifyBlobInclusion(ctx, height, blob, n.GetNodes()...)
+}
+
+// verifyBlobInclusion checks that the client serves the blob with the commitment at the given height and proves its inclusion.
+func verifyBlobInclusion(ctx context.Context, client *RPCClient, height uint64, blob *share.Blob, commitment []byte) error {
+	got, err := client.Blob.Get(ctx, height, blob.Namespace(), commitment)
+	if err != nil {
+		return fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(got.Data)
+	if err != nil {
+		return fmt.Errorf("failed to decode blob data: %w", err)
+	}
+	if !bytes.Equal(data, blob.Data()) {
+		return fmt.Errorf("blob data does not match the submitted data")
+	}
+
+	gotCommitment, err := base64.StdEncoding.DecodeString(got.Commitment)
+	if err != nil {
+		return fmt.Errorf("failed to decode blob commitment: %w", err)
+	}
+	if !bytes.Equal(gotCommitment, commitment) {
+		return fmt.Errorf("blob commitment %X does not match the expected commitment %X", gotCommitment, commitment)
+	}
+
+	proof, err := client.Blob.GetProof(ctx, height, blob.Namespace(), commitment)
+	if err != nil {
+		return fmt.Errorf("failed to get blob proof: %w", err)
+	}
+	if len(proof) == 0 {
+		return fmt.Errorf("blob proof is empty")
+	}
+
+	included, err := client.Blob.Included(ctx, height, blob.Namespace(), proof, commitment)
+	if err != nil {
+		return fmt.Errorf("failed to check blob inclusion: %w", err)
+	}
+	if !included {
+		return fmt.Errorf("blob proof does not prove inclusion at height %d", height)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package dataavailability
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/celestiaorg/go-square/v3/share"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRPCBlob(t *testing.T) {
+	ns := share.MustNewV0Namespace([]byte("tastora"))
+	blob, err := share.NewV0Blob(ns, []byte("hello world"))
+	require.NoError(t, err)
+
+	rpcBlob, err := NewRPCBlob(blob)
+	require.NoError(t, err)
+
+	require.Equal(t, base64.StdEncoding.EncodeToString(ns.Bytes()), rpcBlob.Namespace)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("hello world")), rpcBlob.Data)
+	require.Equal(t, 0, rpcBlob.ShareVersion)
+	require.Empty(t, rpcBlob.Signer)
+
+	commitment, err := BlobCommitment(blob)
+	require.NoError(t, err)
+	require.Len(t, commitment, 32)
+	require.Equal(t, base64.StdEncoding.EncodeToString(commitment), rpcBlob.Commitment)
+}
+
+func TestBlobInclusionReport(t *testing.T) {
+	report := BlobInclusionReport{
+		Results: []BlobInclusionResult{
+			{Node: "bridge-0", NodeType: types.BridgeNode},
+			{Node: "light-0", NodeType: types.LightNode, Err: errors.New("blob: not found")},
+		},
+	}
+
+	require.Equal(t, []string{"bridge-0"}, report.ServedBy())
+	require.ErrorContains(t, report.Err(), "light-0")
+
+	report.Results[1].Err = nil
+	require.NoError(t, report.Err())
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"github.com/celestiaorg/tastora/framework/types"
-	"testing"
 
+	"github.com/celestiaorg/tastora/framework/docker/artifacts"
 	"github.com/celestiaorg/tastora/framework/docker/container"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
@@ -14,7 +14,7 @@ import (
 // NetworkBuilder defines a builder for configuring and initializing a da Network for testing purposes
 type NetworkBuilder struct {
 	// t is the testing context used for test assertions, container naming, and test lifecycle management
-	t *testing.T
+	t types.TestingT
 	// testName is the unique test identifier used for Docker resource naming in parallel execution
 	testName string
 	// nodes is the array of node configurations that define the network topology and individual node settings
@@ -37,15 +37,17 @@ type NetworkBuilder struct {
 	binaryName string
 	// homeDir overrides the default home directory inside the container
 	homeDir string
+	// skipInit indicates whether to skip node initialization on start (useful when reusing volumes)
+	skipInit bool
 }
 
 // NewNetworkBuilder initializes and returns a new NetworkBuilder with default values for testing purposes
-func NewNetworkBuilder(t *testing.T) *NetworkBuilder {
+func NewNetworkBuilder(t types.TestingT) *NetworkBuilder {
 	return NewNetworkBuilderWithTestName(t, t.Name())
 }
 
 // NewNetworkBuilderWithTestName initializes and returns a new NetworkBuilder with a custom test name
-func NewNetworkBuilderWithTestName(t *testing.T, testName string) *NetworkBuilder {
+func NewNetworkBuilderWithTestName(t types.TestingT, testName string) *NetworkBuilder {
 	t.Helper()
 	return &NetworkBuilder{
 		t:                   t,
@@ -146,6 +148,12 @@ func (b *NetworkBuilder) WithNodes(nodeConfigs ...NodeConfig) *NetworkBuilder {
 	return b
 }
 
+// WithSkipInit sets whether to skip node initialization on start (useful when reusing volumes from a previous network)
+func (b *NetworkBuilder) WithSkipInit(skip bool) *NetworkBuilder {
+	b.skipInit = skip
+	return b
+}
+
 // Build creates and returns a new Network instance
 func (b *NetworkBuilder) Build(ctx context.Context) (*Network, error) {
 	if err := b.validate(); err != nil {
@@ -238,6 +246,8 @@ func (b *NetworkBuilder) newNode(ctx context.Context, nodeConfig NodeConfig, ind
 	}
 
 	node := NewNode(cfg, b.testName, imageToUse, index, nodeConfig)
+	node.skipInit = b.skipInit
+	artifacts.Register(b.dockerClient.CleanupLabel(), node)
 
 	// Create and setup volume using shared logic
 	if err := node.CreateAndSetupVolume(ctx, node.Name()); err != nil {
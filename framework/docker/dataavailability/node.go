@@ -4,12 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"path"
 	"regexp"
-	"strconv"
 	"sync"
 
 	"github.com/celestiaorg/go-square/v3/share"
@@ -72,8 +70,12 @@ type Node struct {
 	wallet        *types.Wallet
 	// adminAuthToken is a token that has admin access, it should be generated after init.
 	adminAuthToken string
+	// portsMu guards the external ports, which are read concurrently by subscriptions and RPC clients.
+	portsMu sync.RWMutex
 	// External ports that are resolvable from the test runners themselves.
 	externalPorts types.Ports
+	// skipInit indicates that the node's volume already contains an initialized store and keyring.
+	skipInit bool
 }
 
 func NewNode(cfg Config, testName string, image container.Image, index int, nodeConfig NodeConfig) *Node {
@@ -140,6 +142,9 @@ func (n *Node) GetNetworkInfo(ctx context.Context) (types.NetworkInfo, error) {
 		return types.NetworkInfo{}, err
 	}
 
+	n.portsMu.RLock()
+	defer n.portsMu.RUnlock()
+
 	return types.NetworkInfo{
 		Internal: types.Network{
 			Hostname: n.HostName(),
@@ -186,7 +191,11 @@ func (n *Node) startAndInitialize(ctx context.Context, opts ...StartOption) erro
 	// merge with node-level env vars
 	env = append(env, n.cfg.Env...)
 
-	if err := n.initNode(ctx, startOpts.ChainID, env); err != nil {
+	if n.skipInit {
+		if err := n.loadWallet(ctx); err != nil {
+			return fmt.Errorf("failed to load wallet: %w", err)
+		}
+	} else if err := n.initNode(ctx, startOpts.ChainID, env); err != nil {
 		return fmt.Errorf("failed to initialize da node: %w", err)
 	}
 
@@ -241,6 +250,18 @@ func (n *Node) createWallet(ctx context.Context) error {
 	return nil
 }
 
+// loadWallet loads the wallet from a keyring which already exists in the node's volume.
+func (n *Node) loadWallet(ctx context.Context) error {
+	cmd := []string{"cel-key", "show", "my-key", "--address", "--node.type", n.nodeType.String(), "--keyring-dir", path.Join(n.HomeDir(), "keys"), "--keyring-backend", "test"}
+	stdout, stderr, err := n.Exec(ctx, n.Logger, cmd, nil)
+	if err != nil {
+		return fmt.Errorf("failed to show wallet (stderr=%q): %w", stderr, err)
+	}
+
+	n.wallet = types.NewWallet(nil, string(bytes.TrimSpace(stdout)), "celestia", "my-key")
+	return nil
+}
+
 // extractAddressFromCreateWalletOutput extracts the address from the output of the create wallet command.
 func extractAddressFromCreateWalletOutput(output string) string {
 	re := regexp.MustCompile(`"address"\s*:\s*"([^"]+)"`)
@@ -272,10 +293,12 @@ func (n *Node) startNode(ctx context.Context, additionalStartArgs []string, conf
 		return err
 	}
 
+	n.portsMu.Lock()
 	n.externalPorts = types.Ports{
 		RPC: internal.MustExtractPort(hostPorts[0]),
 		P2P: internal.MustExtractPort(hostPorts[1]),
 	}
+	n.portsMu.Unlock()
 	return nil
 }
 
@@ -353,26 +376,24 @@ func disableRPCAuthModification() map[string]tomlutil.Toml {
 // JSON RPC method implementations
 
 // GetHeader fetches a header for the given block height from the DANode via an RPC call and returns it.
+// Use RPCClient().Header to access the full ExtendedHeader.
 func (n *Node) GetHeader(ctx context.Context, height uint64) (types.Header, error) {
-	url := fmt.Sprintf("http://0.0.0.0:%s", n.externalPorts.RPC)
-
-	result, err := callRPC[HeaderResult](ctx, url, "header.GetByHeight", []uint64{height})
+	header, err := n.RPCClient().Header.GetByHeight(ctx, height)
 	if err != nil {
 		return types.Header{}, err
 	}
 
-	h, err := strconv.Atoi(result.Header.Height)
+	h, err := header.Height()
 	if err != nil {
-		return types.Header{}, fmt.Errorf("failed to parse header height: %w", err)
+		return types.Header{}, err
 	}
 
-	return types.Header{Height: uint64(h)}, nil
+	return types.Header{Height: h}, nil
 }
 
 // GetAllBlobs retrieves all blobs from the node for the specified height and namespaces via an RPC call.
 func (n *Node) GetAllBlobs(ctx context.Context, height uint64, namespaces []share.Namespace) ([]types.Blob, error) {
-	url := fmt.Sprintf("http://0.0.0.0:%s", n.externalPorts.RPC)
-	result, err := callRPC[[]types.Blob](ctx, url, "blob.GetAll", []any{height, namespaces})
+	result, err := n.RPCClient().Blob.GetAll(ctx, height, namespaces)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch blobs: %w", err)
 	}
@@ -381,76 +402,47 @@ func (n *Node) GetAllBlobs(ctx context.Context, height uint64, namespaces []shar
 
 // GetP2PInfo retrieves the p2p information of the node, such as PeerID and Addresses, via an RPC call.
 func (n *Node) GetP2PInfo(ctx context.Context) (types.P2PInfo, error) {
-	url := fmt.Sprintf("http://0.0.0.0:%s", n.externalPorts.RPC)
-	p2pInfo, err := callRPC[types.P2PInfo](ctx, url, "p2p.Info", []any{})
+	p2pInfo, err := n.RPCClient().P2P.Info(ctx)
 	if err != nil {
 		return types.P2PInfo{}, fmt.Errorf("failed to fetch p2p info: %w", err)
 	}
 	return p2pInfo, nil
 }
 
-// Supporting types for JSON RPC
-
-type HeaderResult struct {
-	Header Header `json:"header"`
-}
-
-type Header struct {
-	Height string `json:"height"`
-}
-
-type RPCError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-}
-
-// RPCResponse is a Generic RPC response.
-type RPCResponse[T any] struct {
-	Result T         `json:"result"`
-	Error  *RPCError `json:"error"`
+// HeadStatus reports the header heights of a node when its artifacts are collected.
+type HeadStatus struct {
+	LocalHeadHeight   string `json:"local_head_height,omitempty"`
+	NetworkHeadHeight string `json:"network_head_height,omitempty"`
 }
 
-// callRPC sends a JSON-RPC POST request and unmarshals the result into a typed response.
-func callRPC[T any](ctx context.Context, url, method string, params interface{}) (T, error) {
-	var zero T
-
-	payload := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      1,
-		"method":  method,
-		"params":  params,
-	}
-
-	body, err := json.Marshal(payload)
+// Artifacts returns the config file of the node and the heights of its local and network head headers.
+// It implements artifacts.Source so that they are collected when a test fails.
+func (n *Node) Artifacts(ctx context.Context) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	var errs []error
+	if bz, err := n.ReadFile(ctx, "config.toml"); err != nil {
+		errs = append(errs, err)
+	} else {
+		files["config.toml"] = bz
+	}
+
+	client := n.RPCClient()
+	var status HeadStatus
+	if local, err := client.Header.LocalHead(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch local head: %w", err))
+	} else {
+		status.LocalHeadHeight = local.Header.Height
+	}
+	if network, err := client.Header.NetworkHead(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch network head: %w", err))
+	} else {
+		status.NetworkHeadHeight = network.Header.Height
+	}
+	bz, err := json.MarshalIndent(status, "", "  ")
 	if err != nil {
-		return zero, fmt.Errorf("failed to marshal JSON payload: %w", err)
+		errs = append(errs, fmt.Errorf("failed to marshal head status: %w", err))
+	} else {
+		files["status.json"] = bz
 	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	if err != nil {
-		return zero, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return zero, fmt.Errorf("HTTP request failed: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		data, _ := io.ReadAll(resp.Body)
-		return zero, fmt.Errorf("unexpected status code: %s, body: %s", resp.Status, data)
-	}
-
-	var rpcResp RPCResponse[T]
-	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
-		return zero, fmt.Errorf("failed to decode JSON response: %w", err)
-	}
-
-	if rpcResp.Error != nil {
-		return zero, fmt.Errorf("rpc error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
-	}
-
-	return rpcResp.Result, nil
+	return files, errors.Join(errs...)
 }
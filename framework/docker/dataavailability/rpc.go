@@ -0,0 +1,349 @@
+package dataavailability
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/go-square/v3/share"
+	"github.com/celestiaorg/tastora/framework/types"
+)
+
+// RPCClient is a typed client for the JSON-RPC API of a celestia-node.
+// Methods are grouped by the module of the API they belong to, e.g. client.Blob.Submit calls blob.Submit.
+type RPCClient struct {
+	url        string
+	authToken  string
+	httpClient *http.Client
+
+	Blob   *BlobAPI
+	Share  *ShareAPI
+	Header *HeaderAPI
+	State  *StateAPI
+	DAS    *DASAPI
+	P2P    *P2PAPI
+	Fraud  *FraudAPI
+}
+
+// NewRPCClient returns a client for the JSON-RPC API served at url. If authToken is non-empty it is sent
+// as a bearer token with every request, which is required unless the node runs with RPC.SkipAuth.
+func NewRPCClient(url, authToken string) *RPCClient {
+	c := &RPCClient{
+		url:        url,
+		authToken:  authToken,
+		httpClient: http.DefaultClient,
+	}
+	c.Blob = &BlobAPI{c: c}
+	c.Share = &ShareAPI{c: c}
+	c.Header = &HeaderAPI{c: c}
+	c.State = &StateAPI{c: c}
+	c.DAS = &DASAPI{c: c}
+	c.P2P = &P2PAPI{c: c}
+	c.Fraud = &FraudAPI{c: c}
+	return c
+}
+
+// RPCClient returns a client for the JSON-RPC API of the node, authenticated with the node's admin token.
+// The node must have been started, as the RPC port is only published once its container is running.
+func (n *Node) RPCClient() *RPCClient {
+	n.portsMu.RLock()
+	defer n.portsMu.RUnlock()
+	return NewRPCClient(fmt.Sprintf("http://0.0.0.0:%s", n.externalPorts.RPC), n.adminAuthToken)
+}
+
+// call sends a JSON-RPC request for the method and unmarshals its result into result, which may be nil
+// for methods without a return value.
+func (c *RPCClient) call(ctx context.Context, method string, result any, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %s, body: %s", resp.Status, data)
+	}
+
+	var rpcResp RPCResponse[json.RawMessage]
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s: %w", method, rpcResp.Error)
+	}
+
+	if result == nil || len(rpcResp.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("failed to decode result of %s: %w", method, err)
+	}
+	return nil
+}
+
+// BlobAPI wraps the blob module, which submits and retrieves blobs and their inclusion proofs.
+type BlobAPI struct{ c *RPCClient }
+
+// Submit submits the blobs in a single PayForBlobs transaction signed by the node's key, or the key set
+// in the options, and returns the height it was included at. A nil options uses the node's defaults.
+func (a *BlobAPI) Submit(ctx context.Context, blobs []types.Blob, options *TxConfig) (uint64, error) {
+	if options == nil {
+		options = &TxConfig{}
+	}
+	var height uint64
+	if err := a.c.call(ctx, "blob.Submit", &height, blobs, options); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// Get returns the blob with the commitment in the namespace at the given height.
+func (a *BlobAPI) Get(ctx context.Context, height uint64, namespace share.Namespace, commitment []byte) (types.Blob, error) {
+	var blob types.Blob
+	err := a.c.call(ctx, "blob.Get", &blob, height, namespace, commitment)
+	return blob, err
+}
+
+// GetAll returns all blobs in the namespaces at the given height.
+func (a *BlobAPI) GetAll(ctx context.Context, height uint64, namespaces []share.Namespace) ([]types.Blob, error) {
+	var blobs []types.Blob
+	err := a.c.call(ctx, "blob.GetAll", &blobs, height, namespaces)
+	return blobs, err
+}
+
+// GetProof returns the NMT proofs of the shares of the blob with the commitment against the row roots of the
+// data square at the given height.
+func (a *BlobAPI) GetProof(ctx context.Context, height uint64, namespace share.Namespace, commitment []byte) (BlobProof, error) {
+	var proof BlobProof
+	err := a.c.call(ctx, "blob.GetProof", &proof, height, namespace, commitment)
+	return proof, err
+}
+
+// Included checks whether the blob with the commitment is included at the given height using the proof.
+func (a *BlobAPI) Included(ctx context.Context, height uint64, namespace share.Namespace, proof BlobProof, commitment []byte) (bool, error) {
+	var included bool
+	err := a.c.call(ctx, "blob.Included", &included, height, namespace, proof, commitment)
+	return included, err
+}
+
+// ShareAPI wraps the share module, which retrieves shares of the extended data square.
+type ShareAPI struct{ c *RPCClient }
+
+// SharesAvailable checks whether the data square at the given height is available, by sampling it on light nodes.
+func (a *ShareAPI) SharesAvailable(ctx context.Context, height uint64) error {
+	return a.c.call(ctx, "share.SharesAvailable", nil, height)
+}
+
+// GetSamples returns the shares at the coordinates of the extended data square of the header, with their proofs.
+func (a *ShareAPI) GetSamples(ctx context.Context, header ExtendedHeader, coords []SampleCoords) ([]Sample, error) {
+	var samples []Sample
+	err := a.c.call(ctx, "share.GetSamples", &samples, header, coords)
+	return samples, err
+}
+
+// GetEDS returns the full extended data square at the given height.
+func (a *ShareAPI) GetEDS(ctx context.Context, height uint64) (ExtendedDataSquare, error) {
+	var eds ExtendedDataSquare
+	err := a.c.call(ctx, "share.GetEDS", &eds, height)
+	return eds, err
+}
+
+// GetNamespaceData returns the shares of the namespace at the given height, with a proof per row.
+func (a *ShareAPI) GetNamespaceData(ctx context.Context, height uint64, namespace share.Namespace) ([]RowNamespaceData, error) {
+	var rows []RowNamespaceData
+	err := a.c.call(ctx, "share.GetNamespaceData", &rows, height, namespace)
+	return rows, err
+}
+
+// HeaderAPI wraps the header module, which serves the extended headers synced by the node.
+type HeaderAPI struct{ c *RPCClient }
+
+// LocalHead returns the latest header stored by the node.
+func (a *HeaderAPI) LocalHead(ctx context.Context) (ExtendedHeader, error) {
+	var h ExtendedHeader
+	err := a.c.call(ctx, "header.LocalHead", &h)
+	return h, err
+}
+
+// NetworkHead returns the latest header known to the network.
+func (a *HeaderAPI) NetworkHead(ctx context.Context) (ExtendedHeader, error) {
+	var h ExtendedHeader
+	err := a.c.call(ctx, "header.NetworkHead", &h)
+	return h, err
+}
+
+// GetByHeight returns the header at the given height, blocking until the node has synced it.
+func (a *HeaderAPI) GetByHeight(ctx context.Context, height uint64) (ExtendedHeader, error) {
+	var h ExtendedHeader
+	err := a.c.call(ctx, "header.GetByHeight", &h, height)
+	return h, err
+}
+
+// WaitForHeight blocks until the node has synced the header at the given height and returns it.
+func (a *HeaderAPI) WaitForHeight(ctx context.Context, height uint64) (ExtendedHeader, error) {
+	var h ExtendedHeader
+	err := a.c.call(ctx, "header.WaitForHeight", &h, height)
+	return h, err
+}
+
+// SyncState returns the state of the node's header syncer.
+func (a *HeaderAPI) SyncState(ctx context.Context) (SyncState, error) {
+	var s SyncState
+	err := a.c.call(ctx, "header.SyncState", &s)
+	return s, err
+}
+
+// SyncWait blocks until the node's header syncer has caught up with the network head.
+func (a *HeaderAPI) SyncWait(ctx context.Context) error {
+	return a.c.call(ctx, "header.SyncWait", nil)
+}
+
+// StateAPI wraps the state module, which queries and spends the funds of the node's account.
+type StateAPI struct{ c *RPCClient }
+
+// AccountAddress returns the bech32 address of the node's account.
+func (a *StateAPI) AccountAddress(ctx context.Context) (string, error) {
+	var addr string
+	err := a.c.call(ctx, "state.AccountAddress", &addr)
+	return addr, err
+}
+
+// Balance returns the balance of the node's account.
+func (a *StateAPI) Balance(ctx context.Context) (Balance, error) {
+	var b Balance
+	err := a.c.call(ctx, "state.Balance", &b)
+	return b, err
+}
+
+// BalanceForAddress returns the balance of the bech32 address.
+func (a *StateAPI) BalanceForAddress(ctx context.Context, address string) (Balance, error) {
+	var b Balance
+	err := a.c.call(ctx, "state.BalanceForAddress", &b, address)
+	return b, err
+}
+
+// Transfer sends the amount of the fee denom from the node's account to the bech32 address.
+// A nil options uses the node's defaults.
+func (a *StateAPI) Transfer(ctx context.Context, to string, amount sdkmath.Int, options *TxConfig) (TxResponse, error) {
+	if options == nil {
+		options = &TxConfig{}
+	}
+	var resp TxResponse
+	err := a.c.call(ctx, "state.Transfer", &resp, to, amount, options)
+	return resp, err
+}
+
+// DASAPI wraps the das module, which reports the progress of data availability sampling on light nodes.
+type DASAPI struct{ c *RPCClient }
+
+// SamplingStats returns the current statistics of the sampler.
+func (a *DASAPI) SamplingStats(ctx context.Context) (SamplingStats, error) {
+	var s SamplingStats
+	err := a.c.call(ctx, "das.SamplingStats", &s)
+	return s, err
+}
+
+// WaitCatchUp blocks until the sampler has sampled every header up to the network head.
+func (a *DASAPI) WaitCatchUp(ctx context.Context) error {
+	return a.c.call(ctx, "das.WaitCatchUp", nil)
+}
+
+// P2PAPI wraps the p2p module, which manages the peers of the node.
+type P2PAPI struct{ c *RPCClient }
+
+// Info returns the peer ID and listen addresses of the node.
+func (a *P2PAPI) Info(ctx context.Context) (types.P2PInfo, error) {
+	var info types.P2PInfo
+	err := a.c.call(ctx, "p2p.Info", &info)
+	return info, err
+}
+
+// Peers returns the IDs of the peers the node is connected to.
+func (a *P2PAPI) Peers(ctx context.Context) ([]string, error) {
+	var peers []string
+	err := a.c.call(ctx, "p2p.Peers", &peers)
+	return peers, err
+}
+
+// PeerInfo returns the addresses of a peer known to the node.
+func (a *P2PAPI) PeerInfo(ctx context.Context, peerID string) (types.P2PInfo, error) {
+	var info types.P2PInfo
+	err := a.c.call(ctx, "p2p.PeerInfo", &info, peerID)
+	return info, err
+}
+
+// Connect connects the node to the peer.
+func (a *P2PAPI) Connect(ctx context.Context, peer types.P2PInfo) error {
+	return a.c.call(ctx, "p2p.Connect", nil, peer)
+}
+
+// ClosePeer closes the connections of the node to the peer.
+func (a *P2PAPI) ClosePeer(ctx context.Context, peerID string) error {
+	return a.c.call(ctx, "p2p.ClosePeer", nil, peerID)
+}
+
+// Connectedness returns the state of the connection of the node to the peer.
+func (a *P2PAPI) Connectedness(ctx context.Context, peerID string) (Connectedness, error) {
+	var c Connectedness
+	err := a.c.call(ctx, "p2p.Connectedness", &c, peerID)
+	return c, err
+}
+
+// BlockPeer closes the connections to the peer and prevents the node from connecting to it again.
+func (a *P2PAPI) BlockPeer(ctx context.Context, peerID string) error {
+	return a.c.call(ctx, "p2p.BlockPeer", nil, peerID)
+}
+
+// UnblockPeer allows the node to connect to a previously blocked peer.
+func (a *P2PAPI) UnblockPeer(ctx context.Context, peerID string) error {
+	return a.c.call(ctx, "p2p.UnblockPeer", nil, peerID)
+}
+
+// ListBlockedPeers returns the IDs of the peers blocked by the node.
+func (a *P2PAPI) ListBlockedPeers(ctx context.Context) ([]string, error) {
+	var peers []string
+	err := a.c.call(ctx, "p2p.ListBlockedPeers", &peers)
+	return peers, err
+}
+
+// FraudAPI wraps the fraud module, which serves the fraud proofs received by the node.
+type FraudAPI struct{ c *RPCClient }
+
+// Get returns the fraud proofs of the given type, e.g. "badencoding", received by the node.
+// The proofs are returned undecoded as their encoding depends on the proof type.
+func (a *FraudAPI) Get(ctx context.Context, proofType string) ([]json.RawMessage, error) {
+	var proofs []json.RawMessage
+	err := a.c.call(ctx, "fraud.Get", &proofs, proofType)
+	return proofs, err
+}
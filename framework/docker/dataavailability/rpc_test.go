@@ -0,0 +1,79 @@
+package dataavailability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/celestiaorg/go-square/v3/share"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer returns a server answering every request with the given result or error and recording the
+// last request it received.
+func newTestServer(t *testing.T, result any, rpcErr *RPCError) (*httptest.Server, *http.Request, *map[string]json.RawMessage) {
+	t.Helper()
+	var (
+		lastReq  http.Request
+		lastBody map[string]json.RawMessage
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastReq = *r
+		if err := json.NewDecoder(r.Body).Decode(&lastBody); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resultBz, _ := json.Marshal(result)
+		_ = json.NewEncoder(w).Encode(RPCResponse[json.RawMessage]{Result: resultBz, Error: rpcErr})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &lastReq, &lastBody
+}
+
+func TestRPCClientCall(t *testing.T) {
+	srv, req, body := newTestServer(t, map[string]any{
+		"header": map[string]any{"chain_id": "test", "height": "42"},
+		"dah":    map[string]any{"row_roots": [][]byte{{1}, {2}}, "column_roots": [][]byte{{3}, {4}}},
+	}, nil)
+
+	client := NewRPCClient(srv.URL, "secret")
+	header, err := client.Header.GetByHeight(context.Background(), 42)
+	require.NoError(t, err)
+
+	require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+	require.JSONEq(t, `"header.GetByHeight"`, string((*body)["method"]))
+	require.JSONEq(t, `[42]`, string((*body)["params"]))
+
+	height, err := header.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), height)
+	require.Equal(t, "test", header.Header.ChainID)
+	require.Equal(t, 2, header.DAH.SquareWidth())
+}
+
+func TestRPCClientCallWithoutParams(t *testing.T) {
+	srv, req, body := newTestServer(t, []string{"peer-a", "peer-b"}, nil)
+
+	client := NewRPCClient(srv.URL, "")
+	peers, err := client.P2P.Peers(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"peer-a", "peer-b"}, peers)
+
+	require.Empty(t, req.Header.Get("Authorization"))
+	require.JSONEq(t, `[]`, string((*body)["params"]))
+}
+
+func TestRPCClientError(t *testing.T) {
+	srv, _, _ := newTestServer(t, nil, &RPCError{Code: 1, Message: "blob: not found"})
+
+	client := NewRPCClient(srv.URL, "")
+	_, err := client.Blob.Get(context.Background(), 1, share.MustNewV0Namespace([]byte("test")), []byte{1})
+	require.Error(t, err)
+
+	var rpcErr *RPCError
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, "blob: not found", rpcErr.Message)
+}
@@ -0,0 +1,247 @@
+package dataavailability
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+)
+
+// Types of the celestia-node JSON-RPC API. They mirror the JSON encoding of the node's types so that
+// values returned by one method, e.g. a header, can be passed back to another.
+
+// RPCError is an error returned by the node for a JSON-RPC request.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// RPCResponse is a Generic RPC response.
+type RPCResponse[T any] struct {
+	Result T         `json:"result"`
+	Error  *RPCError `json:"error"`
+}
+
+// ExtendedHeader is a block header extended with the validator set and commit which signed it and the
+// data availability header of the block's extended data square.
+type ExtendedHeader struct {
+	Header       Header                 `json:"header"`
+	ValidatorSet ValidatorSet           `json:"validator_set"`
+	Commit       Commit                 `json:"commit"`
+	DAH          DataAvailabilityHeader `json:"dah"`
+}
+
+// Height returns the height of the header.
+func (h ExtendedHeader) Height() (uint64, error) {
+	height, err := strconv.ParseUint(h.Header.Height, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse header height: %w", err)
+	}
+	return height, nil
+}
+
+// HeaderResult is the result of the header methods of the API.
+//
+// Deprecated: use ExtendedHeader.
+type HeaderResult = ExtendedHeader
+
+// Header is a CometBFT block header. Integers are encoded as strings.
+type Header struct {
+	Version struct {
+		Block string `json:"block"`
+		App   string `json:"app"`
+	} `json:"version"`
+	ChainID            string            `json:"chain_id"`
+	Height             string            `json:"height"`
+	Time               time.Time         `json:"time"`
+	LastBlockID        BlockID           `json:"last_block_id"`
+	LastCommitHash     cmtbytes.HexBytes `json:"last_commit_hash"`
+	DataHash           cmtbytes.HexBytes `json:"data_hash"`
+	ValidatorsHash     cmtbytes.HexBytes `json:"validators_hash"`
+	NextValidatorsHash cmtbytes.HexBytes `json:"next_validators_hash"`
+	ConsensusHash      cmtbytes.HexBytes `json:"consensus_hash"`
+	AppHash            cmtbytes.HexBytes `json:"app_hash"`
+	LastResultsHash    cmtbytes.HexBytes `json:"last_results_hash"`
+	EvidenceHash       cmtbytes.HexBytes `json:"evidence_hash"`
+	ProposerAddress    cmtbytes.HexBytes `json:"proposer_address"`
+}
+
+// BlockID identifies a block by the hash of its header and of its parts.
+type BlockID struct {
+	Hash          cmtbytes.HexBytes `json:"hash"`
+	PartSetHeader struct {
+		Total int               `json:"total"`
+		Hash  cmtbytes.HexBytes `json:"hash"`
+	} `json:"parts"`
+}
+
+// ValidatorSet is the set of validators which signed a header.
+type ValidatorSet struct {
+	Validators []Validator `json:"validators"`
+	Proposer   *Validator  `json:"proposer"`
+}
+
+// Validator is a member of a ValidatorSet. The public key is kept in its amino JSON encoding.
+type Validator struct {
+	Address          cmtbytes.HexBytes `json:"address"`
+	PubKey           json.RawMessage   `json:"pub_key"`
+	VotingPower      string            `json:"voting_power"`
+	ProposerPriority string            `json:"proposer_priority"`
+}
+
+// Commit contains the signatures of the validators which committed a header.
+type Commit struct {
+	Height     string      `json:"height"`
+	Round      int32       `json:"round"`
+	BlockID    BlockID     `json:"block_id"`
+	Signatures []CommitSig `json:"signatures"`
+}
+
+// CommitSig is the signature of a validator in a Commit.
+type CommitSig struct {
+	BlockIDFlag      int               `json:"block_id_flag"`
+	ValidatorAddress cmtbytes.HexBytes `json:"validator_address"`
+	Timestamp        time.Time         `json:"timestamp"`
+	Signature        []byte            `json:"signature"`
+}
+
+// DataAvailabilityHeader contains the row and column roots of the extended data square of a block.
+type DataAvailabilityHeader struct {
+	RowRoots    [][]byte `json:"row_roots"`
+	ColumnRoots [][]byte `json:"column_roots"`
+}
+
+// SquareWidth returns the width of the extended data square.
+func (d DataAvailabilityHeader) SquareWidth() int {
+	return len(d.RowRoots)
+}
+
+// NMTProof is a namespaced merkle tree proof of a range of shares against a row root.
+type NMTProof struct {
+	Start                   int      `json:"start"`
+	End                     int      `json:"end"`
+	Nodes                   [][]byte `json:"nodes"`
+	LeafHash                []byte   `json:"leaf_hash"`
+	IsMaxNamespaceIDIgnored bool     `json:"is_max_namespace_ignored"`
+}
+
+// BlobProof proves the inclusion of the shares of a blob, with one proof for every row the blob spans.
+type BlobProof []*NMTProof
+
+// SampleCoords are the coordinates of a share in the extended data square.
+type SampleCoords struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// Sample is a share of the extended data square with its proof against the row or column root.
+type Sample struct {
+	Share     []byte    `json:"share"`
+	Proof     *NMTProof `json:"proof"`
+	ProofType int       `json:"proof_type"`
+}
+
+// ExtendedDataSquare is the erasure coded data square of a block.
+type ExtendedDataSquare struct {
+	DataSquare [][]byte `json:"data_square"`
+	Codec      string   `json:"codec"`
+}
+
+// RowNamespaceData contains the shares of a namespace in a single row with their proof.
+type RowNamespaceData struct {
+	Shares [][]byte  `json:"shares"`
+	Proof  *NMTProof `json:"proof"`
+}
+
+// SyncState is the state of the header syncer of a node.
+type SyncState struct {
+	ID         uint64    `json:"id"`
+	Height     uint64    `json:"height"`
+	FromHeight uint64    `json:"from_height"`
+	ToHeight   uint64    `json:"to_height"`
+	FromHash   string    `json:"from_hash"`
+	ToHash     string    `json:"to_hash"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Finished reports whether the syncer has synced up to its target height.
+func (s SyncState) Finished() bool {
+	return s.ToHeight <= s.Height
+}
+
+// TxConfig configures a transaction submitted by the node. Zero values use the node's defaults.
+type TxConfig struct {
+	GasPrice          float64 `json:"gas_price,omitempty"`
+	IsGasPriceSet     bool    `json:"is_gas_price_set,omitempty"`
+	MaxGasPrice       float64 `json:"max_gas_price,omitempty"`
+	Gas               uint64  `json:"gas,omitempty"`
+	TxPriority        int     `json:"tx_priority,omitempty"`
+	KeyName           string  `json:"key_name,omitempty"`
+	SignerAddress     string  `json:"signer_address,omitempty"`
+	FeeGranterAddress string  `json:"fee_granter_address,omitempty"`
+}
+
+// WithGasPrice returns a copy of the config using the gas price.
+func (c TxConfig) WithGasPrice(gasPrice float64) *TxConfig {
+	c.GasPrice = gasPrice
+	c.IsGasPriceSet = true
+	return &c
+}
+
+// TxResponse is the result of a transaction submitted by the node.
+type TxResponse struct {
+	Height    int64  `json:"height"`
+	TxHash    string `json:"txhash"`
+	Codespace string `json:"codespace"`
+	Code      uint32 `json:"code"`
+	RawLog    string `json:"raw_log"`
+	GasWanted int64  `json:"gas_wanted"`
+	GasUsed   int64  `json:"gas_used"`
+}
+
+// Balance is the balance of an account in a single denom.
+type Balance struct {
+	Denom  string      `json:"denom"`
+	Amount sdkmath.Int `json:"amount"`
+}
+
+// SamplingStats reports the progress of the data availability sampler of a node.
+type SamplingStats struct {
+	SampledChainHead uint64         `json:"head_of_sampled_chain"`
+	CatchupHead      uint64         `json:"head_of_catchup"`
+	NetworkHead      uint64         `json:"network_head_height"`
+	Failed           map[uint64]int `json:"failed,omitempty"`
+	Workers          []WorkerStats  `json:"workers,omitempty"`
+	Concurrency      int            `json:"concurrency"`
+	CatchUpDone      bool           `json:"catch_up_done"`
+	IsRunning        bool           `json:"is_running"`
+}
+
+// WorkerStats reports the progress of a single sampling worker.
+type WorkerStats struct {
+	JobType string `json:"job_type"`
+	Curr    uint64 `json:"current"`
+	From    uint64 `json:"from"`
+	To      uint64 `json:"to"`
+	ErrMsg  string `json:"error,omitempty"`
+}
+
+// Connectedness is the state of the connection of a node to a peer.
+type Connectedness int
+
+const (
+	NotConnected Connectedness = iota
+	Connected
+	CanConnect
+	CannotConnect
+	Limited
+)
@@ -0,0 +1,215 @@
+package dataavailability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// Methods sent by the node for values of, and the closing of, a channel returned by a subscription.
+const (
+	chanValueMethod = "xrpc.ch.val"
+	chanCloseMethod = "xrpc.ch.close"
+)
+
+// wsMessage is a JSON-RPC message received over a websocket connection, either the response to the
+// subscription request or a notification for the subscribed channel.
+type wsMessage struct {
+	ID     *int64            `json:"id,omitempty"`
+	Method string            `json:"method,omitempty"`
+	Params []json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage   `json:"result,omitempty"`
+	Error  *RPCError         `json:"error,omitempty"`
+}
+
+// Subscription receives the values the node sends for the channel returned by a subscription method.
+// It uses its own websocket connection, which is closed when the context of the subscription is cancelled.
+type Subscription[T any] struct {
+	values chan T
+
+	mu  sync.Mutex
+	err error
+}
+
+// Values returns the channel receiving the values. It is closed once the subscription ends, either because the
+// context was cancelled, the node closed the channel, the connection to the node was lost or a value could
+// not be decoded, see Err.
+func (s *Subscription[T]) Values() <-chan T {
+	return s.values
+}
+
+// Err returns the error which ended the subscription once Values is closed, nil if the context was cancelled
+// or the node closed the channel.
+func (s *Subscription[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// fail records the error ending the subscription.
+func (s *Subscription[T]) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Subscribe returns a subscription receiving every header synced by the node from now on.
+func (a *HeaderAPI) Subscribe(ctx context.Context) (*Subscription[ExtendedHeader], error) {
+	return subscribe[ExtendedHeader](ctx, a.c, "header.Subscribe")
+}
+
+// SubscribeHeaders returns a channel receiving the height of every header synced by the node from now on.
+// Use RPCClient().Header.Subscribe to receive the full ExtendedHeader.
+func (n *Node) SubscribeHeaders(ctx context.Context) (<-chan types.Header, error) {
+	sub, err := n.RPCClient().Header.Subscribe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to headers of %s: %w", n.Name(), err)
+	}
+
+	out := make(chan types.Header)
+	go func() {
+		defer close(out)
+		defer func() {
+			if err := sub.Err(); err != nil {
+				n.Logger.Error("header subscription ended", zap.Error(err))
+			}
+		}()
+		for h := range sub.Values() {
+			height, err := h.Height()
+			if err != nil {
+				n.Logger.Error("failed to parse subscribed header", zap.Error(err))
+				continue
+			}
+			select {
+			case out <- types.Header{Height: height}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// GetLocalHead returns the latest header stored by the node.
+func (n *Node) GetLocalHead(ctx context.Context) (types.Header, error) {
+	header, err := n.RPCClient().Header.LocalHead(ctx)
+	if err != nil {
+		return types.Header{}, err
+	}
+	height, err := header.Height()
+	if err != nil {
+		return types.Header{}, err
+	}
+	return types.Header{Height: height}, nil
+}
+
+// subscribe calls a method returning a channel over a websocket connection and forwards the values the
+// node sends for the channel to the returned subscription.
+func subscribe[T any](ctx context.Context, c *RPCClient, method string, params ...any) (*Subscription[T], error) {
+	if params == nil {
+		params = []any{}
+	}
+
+	header := http.Header{}
+	if c.authToken != "" {
+		header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, websocketURL(c.url), header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+
+	// closing the connection unblocks any read once the context is cancelled.
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		_ = conn.Close()
+	}()
+
+	chanID, err := startSubscription(conn, method, params)
+	if err != nil {
+		close(done)
+		return nil, err
+	}
+
+	sub := &Subscription[T]{values: make(chan T)}
+	go func() {
+		defer close(sub.values)
+		defer close(done)
+		for {
+			var msg wsMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				if ctx.Err() == nil {
+					sub.fail(fmt.Errorf("connection of %s subscription lost: %w", method, err))
+				}
+				return
+			}
+			if len(msg.Params) == 0 || string(msg.Params[0]) != string(chanID) {
+				continue
+			}
+			switch msg.Method {
+			case chanCloseMethod:
+				return
+			case chanValueMethod:
+				if len(msg.Params) < 2 {
+					continue
+				}
+				var v T
+				if err := json.Unmarshal(msg.Params[1], &v); err != nil {
+					sub.fail(fmt.Errorf("failed to decode %s value: %w", method, err))
+					return
+				}
+				select {
+				case sub.values <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// startSubscription sends the request for the method and returns the ID of the channel the node sends its values for.
+func startSubscription(conn *websocket.Conn, method string, params []any) (json.RawMessage, error) {
+	if err := conn.WriteJSON(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send %s request: %w", method, err)
+	}
+
+	var resp wsMessage
+	if err := conn.ReadJSON(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s: %w", method, resp.Error)
+	}
+	return resp.Result, nil
+}
+
+// websocketURL returns the websocket equivalent of an http(s) URL.
+func websocketURL(url string) string {
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		return "wss://" + strings.TrimPrefix(url, "https://")
+	case strings.HasPrefix(url, "http://"):
+		return "ws://" + strings.TrimPrefix(url, "http://")
+	default:
+		return url
+	}
+}
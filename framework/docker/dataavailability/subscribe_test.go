@@ -0,0 +1,93 @@
+package dataavailability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderSubscribe(t *testing.T) {
+	authHeaders := make(chan string, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders <- r.Header.Get("Authorization")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		var req map[string]any
+		if err := conn.ReadJSON(&req); err != nil || req["method"] != "header.Subscribe" {
+			return
+		}
+		_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": 1, "result": 7})
+		// values for other channels are ignored.
+		_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "method": chanValueMethod, "params": []any{3, map[string]any{"header": map[string]any{"height": "99"}}}})
+		for _, height := range []string{"5", "6"} {
+			_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "method": chanValueMethod, "params": []any{7, map[string]any{"header": map[string]any{"height": height}}}})
+		}
+		_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "method": chanCloseMethod, "params": []any{7}})
+		// keep the connection open until the client closes it.
+		_, _, _ = conn.ReadMessage()
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sub, err := NewRPCClient(srv.URL, "secret").Header.Subscribe(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Bearer secret", <-authHeaders)
+
+	var heights []uint64
+	for h := range sub.Values() {
+		height, err := h.Height()
+		require.NoError(t, err)
+		heights = append(heights, height)
+	}
+	require.Equal(t, []uint64{5, 6}, heights, "the channel should close once the node closes the subscription")
+	require.NoError(t, sub.Err())
+}
+
+func TestHeaderSubscribeDecodeError(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		var req map[string]any
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": 1, "result": 7})
+		_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "method": chanValueMethod, "params": []any{7, "not a header"}})
+		_, _, _ = conn.ReadMessage()
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sub, err := NewRPCClient(srv.URL, "").Header.Subscribe(ctx)
+	require.NoError(t, err)
+
+	for range sub.Values() {
+		t.Fatal("no header should be received")
+	}
+	require.ErrorContains(t, sub.Err(), "failed to decode header.Subscribe value")
+}
+
+func TestWebsocketURL(t *testing.T) {
+	require.Equal(t, "ws://0.0.0.0:26658", websocketURL("http://0.0.0.0:26658"))
+	require.Equal(t, "wss://node.example", websocketURL("https://node.example"))
+	require.Equal(t, "ws://already", websocketURL("ws://already"))
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"testing"
 
+	feegrantmodule "cosmossdk.io/x/feegrant/module"
 	ismtypes "github.com/bcp-innovations/hyperlane-cosmos/x/core/01_interchain_security/types"
 	hooktypes "github.com/bcp-innovations/hyperlane-cosmos/x/core/02_post_dispatch/types"
 	coretypes "github.com/bcp-innovations/hyperlane-cosmos/x/core/types"
@@ -20,8 +21,11 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	authzmodule "github.com/cosmos/cosmos-sdk/x/authz/module"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	govmodule "github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/ibc-go/v8/modules/apps/transfer"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -70,7 +74,10 @@ func setupDockerTest(t *testing.T) *TestSetupConfig {
 	dockerClient, networkID := Setup(t)
 
 	logger := zaptest.NewLogger(t)
-	encConfig := testutil.MakeTestEncodingConfig(auth.AppModuleBasic{}, bank.AppModuleBasic{}, transfer.AppModuleBasic{}, govmodule.AppModuleBasic{})
+	encConfig := testutil.MakeTestEncodingConfig(
+		auth.AppModuleBasic{}, bank.AppModuleBasic{}, transfer.AppModuleBasic{}, govmodule.AppModuleBasic{},
+		staking.AppModuleBasic{}, slashing.AppModuleBasic{}, authzmodule.AppModuleBasic{}, feegrantmodule.AppModuleBasic{},
+	)
 
 	// register hyperlane-cosmos types for message encoding/decoding
 	ismtypes.RegisterInterfaces(encConfig.InterfaceRegistry)
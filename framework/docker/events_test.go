@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/testutil/events"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTxEvents verifies that events of a transaction can be matched and decoded, that failed transactions
+// report their registered error and that events emitted in future blocks can be awaited.
+func TestTxEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	denom := chain.Config.Denom
+	receiver, err := chain.CreateWallet(testCfg.Ctx, "receiver")
+	require.NoError(t, err)
+	faucetAddr, err := sdkacc.AddressFromWallet(chain.GetFaucetWallet())
+	require.NoError(t, err)
+	toAddr, err := sdkacc.AddressFromWallet(receiver)
+	require.NoError(t, err)
+	sendAmount := sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(1_000)))
+
+	t.Run("match events", func(t *testing.T) {
+		resp, err := chain.BroadcastMessages(testCfg.Ctx, chain.GetFaucetWallet(), banktypes.NewMsgSend(faucetAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+		events.RequireTxSuccess(t, resp)
+
+		transfer, ok := events.First(resp.Events, banktypes.EventTypeTransfer, events.Attr(banktypes.AttributeKeyRecipient, receiver.GetFormattedAddress()))
+		require.True(t, ok)
+		amount, ok := events.AttributeOf(transfer, sdk.AttributeKeyAmount)
+		require.True(t, ok)
+		require.Equal(t, sendAmount.String(), amount)
+	})
+
+	t.Run("failed tx code", func(t *testing.T) {
+		// the send fails on execution, after the tx has passed CheckTx.
+		tooMuch := sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewIntWithDecimal(1, 30)))
+		resp, err := chain.BroadcastMessages(testCfg.Ctx, chain.GetFaucetWallet(), banktypes.NewMsgSend(faucetAddr, toAddr, tooMuch))
+		require.NoError(t, err)
+		events.RequireTxCode(t, resp, sdkerrors.ErrInsufficientFunds)
+		require.ErrorIs(t, events.TxError(resp), sdkerrors.ErrInsufficientFunds)
+	})
+
+	t.Run("wait for future tx", func(t *testing.T) {
+		netInfo, err := chain.GetNode().GetNetworkInfo(testCfg.Ctx)
+		require.NoError(t, err)
+		client, err := rpchttp.New("tcp://"+netInfo.External.RPCAddress(), "/websocket")
+		require.NoError(t, err)
+		require.NoError(t, client.Start())
+		t.Cleanup(func() { _ = client.Stop() })
+
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, time.Minute)
+		defer cancel()
+
+		query, err := events.TxQuery(banktypes.EventTypeTransfer, events.Attr(banktypes.AttributeKeyRecipient, receiver.GetFormattedAddress()))
+		require.NoError(t, err)
+		txs := make(chan error, 1)
+		go func() {
+			tx, err := events.WaitForTx(ctx, client, query)
+			if err == nil && len(events.Find(tx.Result.Events, banktypes.EventTypeTransfer)) == 0 {
+				err = errors.New("transfer event not found in tx")
+			}
+			txs <- err
+		}()
+
+		// give the subscription time to be registered before broadcasting.
+		time.Sleep(time.Second)
+		_, err = chain.BroadcastMessages(testCfg.Ctx, chain.GetFaucetWallet(), banktypes.NewMsgSend(faucetAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+		require.NoError(t, <-txs)
+	})
+}
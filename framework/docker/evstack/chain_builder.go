@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/celestiaorg/tastora/framework/types"
-	"testing"
 
 	"github.com/celestiaorg/tastora/framework/docker/container"
 	"go.uber.org/zap"
@@ -14,7 +13,7 @@ import (
 // ChainBuilder defines a builder for configuring and initializing an evstack Chain for testing purposes
 type ChainBuilder struct {
 	// t is the testing context used for test assertions, container naming, and test lifecycle management
-	t *testing.T
+	t types.TestingT
 	// testName is the unique test identifier used for Docker resource naming in parallel execution
 	testName string
 	// nodes is the array of node configurations that define the chain topology and individual node settings
@@ -42,12 +41,12 @@ type ChainBuilder struct {
 }
 
 // NewChainBuilder initializes and returns a new ChainBuilder with default values for testing purposes
-func NewChainBuilder(t *testing.T) *ChainBuilder {
+func NewChainBuilder(t types.TestingT) *ChainBuilder {
 	return NewChainBuilderWithTestName(t, t.Name())
 }
 
 // NewChainBuilderWithTestName initializes and returns a new ChainBuilder with a custom test name
-func NewChainBuilderWithTestName(t *testing.T, testName string) *ChainBuilder {
+func NewChainBuilderWithTestName(t types.TestingT, testName string) *ChainBuilder {
 	t.Helper()
 	return &ChainBuilder{
 		t:                    t,
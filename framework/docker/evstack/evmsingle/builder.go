@@ -3,7 +3,6 @@ package evmsingle
 import (
 	"context"
 	"fmt"
-	"testing"
 
 	"github.com/celestiaorg/tastora/framework/docker/container"
 	"github.com/celestiaorg/tastora/framework/docker/internal"
@@ -14,7 +13,7 @@ import (
 
 // ChainBuilder constructs a Chain and pre-creates node volumes
 type ChainBuilder struct {
-	t            *testing.T
+	t            types.TestingT
 	testName     string
 	logger       *zap.Logger
 	dockerClient types.TastoraDockerClient
@@ -26,13 +25,15 @@ type ChainBuilder struct {
 	nodes        []NodeConfig
 	name         string
 	homeDir      string
+	// skipInit indicates whether to skip running init on start (useful when reusing volumes)
+	skipInit bool
 }
 
-func NewChainBuilder(t *testing.T) *ChainBuilder {
+func NewChainBuilder(t types.TestingT) *ChainBuilder {
 	return NewChainBuilderWithTestName(t, t.Name())
 }
 
-func NewChainBuilderWithTestName(t *testing.T, testName string) *ChainBuilder {
+func NewChainBuilderWithTestName(t types.TestingT, testName string) *ChainBuilder {
 	t.Helper()
 	return (&ChainBuilder{}).
 		WithT(t).
@@ -42,7 +43,7 @@ func NewChainBuilderWithTestName(t *testing.T, testName string) *ChainBuilder {
 		WithBinary(DefaultBinary())
 }
 
-func (b *ChainBuilder) WithT(t *testing.T) *ChainBuilder {
+func (b *ChainBuilder) WithT(t types.TestingT) *ChainBuilder {
 	b.t = t
 	return b
 }
@@ -114,6 +115,12 @@ func (b *ChainBuilder) WithName(name string) *ChainBuilder {
 	return b
 }
 
+// WithSkipInit sets whether to skip running init on start (useful when reusing volumes from a previous chain)
+func (b *ChainBuilder) WithSkipInit(skip bool) *ChainBuilder {
+	b.skipInit = skip
+	return b
+}
+
 // Build constructs a Chain with nodes created and volumes initialized (not isInitialized)
 func (b *ChainBuilder) Build(ctx context.Context) (*Chain, error) {
 	homeDir := b.homeDir
@@ -146,6 +153,8 @@ func (b *ChainBuilder) Build(ctx context.Context) (*Chain, error) {
 		if err != nil {
 			return nil, err
 		}
+		// the volume already contains the config and signer produced by init.
+		n.isInitialized = b.skipInit
 		chain.nodes[n.Name()] = n
 		chain.nextIndex++
 	}
@@ -3,7 +3,6 @@ package reth
 import (
 	"context"
 	"fmt"
-	"testing"
 
 	"github.com/celestiaorg/tastora/framework/docker/container"
 	"github.com/celestiaorg/tastora/framework/docker/internal"
@@ -14,7 +13,7 @@ import (
 
 // NodeBuilder constructs a single Reth Node and builds its Docker resources (volume, etc.).
 type NodeBuilder struct {
-	t                   *testing.T
+	t                   types.TestingT
 	testName            string
 	logger              *zap.Logger
 	dockerClient        types.TastoraDockerClient
@@ -32,11 +31,11 @@ type NodeBuilder struct {
 	hyperlaneDomainID   uint32
 }
 
-func NewNodeBuilder(t *testing.T) *NodeBuilder {
+func NewNodeBuilder(t types.TestingT) *NodeBuilder {
 	return NewNodeBuilderWithTestName(t, t.Name())
 }
 
-func NewNodeBuilderWithTestName(t *testing.T, testName string) *NodeBuilder {
+func NewNodeBuilderWithTestName(t types.TestingT, testName string) *NodeBuilder {
 	t.Helper()
 	return (&NodeBuilder{}).
 		WithT(t).
@@ -46,7 +45,7 @@ func NewNodeBuilderWithTestName(t *testing.T, testName string) *NodeBuilder {
 		WithBin("ev-reth")
 }
 
-func (b *NodeBuilder) WithT(t *testing.T) *NodeBuilder {
+func (b *NodeBuilder) WithT(t types.TestingT) *NodeBuilder {
 	b.t = t
 	return b
 }
@@ -0,0 +1,91 @@
+package docker
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/testutil/wallet"
+	"github.com/celestiaorg/tastora/framework/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExportStateAndRestart verifies that the state of a chain can be exported and used to start a new chain
+// which continues from the exported height with the same validator set and account balances.
+func TestExportStateAndRestart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.
+		WithNodes(
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().WithNodeType(types.NodeTypeConsensusFull).Build(),
+		).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	sendAmount := sdk.NewCoins(sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(1_000_000)))
+	testWallet, err := wallet.CreateAndFund(testCfg.Ctx, "export", sendAmount, chain)
+	require.NoError(t, err)
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+	exported, err := chain.ExportState(testCfg.Ctx, 0)
+	require.NoError(t, err)
+
+	var genesis struct {
+		ChainID       string          `json:"chain_id"`
+		InitialHeight json.RawMessage `json:"initial_height"`
+		AppState      json.RawMessage `json:"app_state"`
+	}
+	require.NoError(t, json.Unmarshal(exported, &genesis))
+	require.Equal(t, chain.GetChainID(), genesis.ChainID)
+	require.NotEmpty(t, genesis.AppState)
+
+	initialHeight, err := strconv.ParseInt(trimQuotes(string(genesis.InitialHeight)), 10, 64)
+	require.NoError(t, err)
+	require.Greater(t, initialHeight, int64(1), "the export should continue from the exported height")
+
+	// the exporting chain keeps producing blocks after the export.
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+	nodeConfigs, err := chain.ExportNodeConfigs(testCfg.Ctx)
+	require.NoError(t, err)
+	require.Len(t, nodeConfigs, 2)
+	require.NoError(t, chain.Remove(testCfg.Ctx))
+
+	forked, err := testCfg.ChainBuilder.
+		WithChainID("forked").
+		WithExportedGenesis(exported).
+		WithNodes(nodeConfigs...).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, forked.Start(testCfg.Ctx))
+
+	height, err := forked.Height(testCfg.Ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, height, initialHeight)
+
+	balance, err := query.Balance(testCfg.Ctx, forked.GetNode().GrpcConn, testWallet.GetFormattedAddress(), forked.Config.Denom)
+	require.NoError(t, err)
+	require.True(t, sendAmount.AmountOf(forked.Config.Denom).Equal(balance), "balances should be carried over from the exported state")
+
+	require.NotNil(t, forked.GetFaucetWallet(), "the faucet key should be carried over to the new chain")
+}
+
+// trimQuotes removes the quotes around a JSON string, as the initial height is encoded as a string by newer SDK versions.
+func trimQuotes(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
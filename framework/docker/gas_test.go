@@ -0,0 +1,119 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/go-square/v3/share"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	"github.com/celestiaorg/tastora/framework/testutil/blobtypes"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGasEstimation verifies that transactions can be simulated to determine their gas limit and signed with a
+// gas price queried from the chain.
+func TestGasEstimation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.
+		WithGas("auto").
+		WithGasPriceSource(cosmos.GasPriceSourceNodeMinimum).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	faucetAddr, err := sdkacc.AddressFromWallet(chain.GetFaucetWallet())
+	require.NoError(t, err)
+	receiver, err := chain.CreateWallet(testCfg.Ctx, "receiver")
+	require.NoError(t, err)
+	toAddr, err := sdkacc.AddressFromWallet(receiver)
+	require.NoError(t, err)
+
+	t.Run("gas prices", func(t *testing.T) {
+		configured, err := sdk.ParseDecCoins(chain.Config.GasPrices)
+		require.NoError(t, err)
+
+		minGasPrices, err := query.MinGasPrices(testCfg.Ctx, chain.GetNode().GrpcConn)
+		require.NoError(t, err)
+		require.True(t, configured.Equal(minGasPrices), "node minimum gas prices %s should be the configured %s", minGasPrices, configured)
+
+		gasPrices, err := chain.GasPrices(testCfg.Ctx)
+		require.NoError(t, err)
+		parsed, err := sdk.ParseDecCoins(gasPrices)
+		require.NoError(t, err)
+		require.True(t, configured.Equal(parsed))
+
+		estimated, err := query.EstimateGasPrice(testCfg.Ctx, chain.GetNode().GrpcConn, query.TxPriorityHigh)
+		require.NoError(t, err)
+		require.Positive(t, estimated)
+	})
+
+	t.Run("simulated gas limit", func(t *testing.T) {
+		resp, err := chain.BroadcastMessages(testCfg.Ctx, chain.GetFaucetWallet(),
+			banktypes.NewMsgSend(faucetAddr, toAddr, sdk.NewCoins(sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(1_000)))))
+		require.NoError(t, err)
+		require.Zero(t, resp.Code)
+		require.Less(t, resp.GasWanted, int64(flags.DefaultGasLimit), "gas limit should be simulated rather than the default")
+		require.GreaterOrEqual(t, resp.GasWanted, resp.GasUsed)
+	})
+
+	t.Run("invalid gas", func(t *testing.T) {
+		_, err := cosmos.NewChainBuilder(t).WithGas("lots").Build(testCfg.Ctx)
+		require.Error(t, err)
+	})
+}
+
+// TestBroadcastLargeBlob verifies that a blob needing more gas than the default gas limit is paid for by both
+// the default broadcaster and the SequencedBroadcaster.
+func TestBroadcastLargeBlob(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+	blobtypes.RegisterInterfaces(chain.Config.EncodingConfig.InterfaceRegistry)
+
+	signer, err := sdkacc.AddressFromWallet(chain.GetFaucetWallet())
+	require.NoError(t, err)
+
+	ns := share.MustNewV0Namespace([]byte("tastora"))
+	blob, err := share.NewV0Blob(ns, bytes.Repeat([]byte{1}, 100_000))
+	require.NoError(t, err)
+	msg, err := blobtypes.NewMsgPayForBlobs(signer.String(), blob)
+	require.NoError(t, err)
+
+	broadcasters := map[string]interface {
+		BroadcastBlobMessage(context.Context, *types.Wallet, sdk.Msg, ...*share.Blob) (sdk.TxResponse, error)
+	}{
+		"default":   chain,
+		"sequenced": cosmos.NewSequencedBroadcaster(chain, nil),
+	}
+	for name, b := range broadcasters {
+		t.Run(name, func(t *testing.T) {
+			resp, err := b.BroadcastBlobMessage(testCfg.Ctx, chain.GetFaucetWallet(), msg, blob)
+			require.NoError(t, err)
+			require.Zero(t, resp.Code, resp.RawLog)
+			require.Greater(t, resp.GasWanted, int64(flags.DefaultGasLimit))
+		})
+	}
+}
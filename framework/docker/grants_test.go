@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/testutil/wallet"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeeGrantsAndAuthz verifies that fees can be paid by a granter or a fee payer, and that messages can be
+// executed on behalf of a granter.
+func TestFeeGrantsAndAuthz(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	denom := chain.Config.Denom
+	funds := sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(10_000_000)))
+	granter, err := wallet.CreateAndFund(testCfg.Ctx, "granter", funds, chain)
+	require.NoError(t, err)
+	grantee, err := wallet.CreateAndFund(testCfg.Ctx, "grantee", funds, chain)
+	require.NoError(t, err)
+	receiver, err := chain.CreateWallet(testCfg.Ctx, "receiver")
+	require.NoError(t, err)
+
+	granteeAddr, err := sdkacc.AddressFromWallet(grantee)
+	require.NoError(t, err)
+	granterAddr, err := sdkacc.AddressFromWallet(granter)
+	require.NoError(t, err)
+	toAddr, err := sdkacc.AddressFromWallet(receiver)
+	require.NoError(t, err)
+	sendAmount := sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(1_000)))
+
+	balanceOf := func(t *testing.T, address string) sdkmath.Int {
+		balance, err := query.Balance(testCfg.Ctx, chain.GetNode().GrpcConn, address, denom)
+		require.NoError(t, err)
+		return balance
+	}
+
+	t.Run("fee granter", func(t *testing.T) {
+		require.NoError(t, chain.GrantFeeAllowance(testCfg.Ctx, granter, grantee, cosmos.FeeAllowance{
+			SpendLimit:      sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(1_000_000))),
+			AllowedMessages: []string{sdk.MsgTypeURL(&banktypes.MsgSend{})},
+		}))
+
+		before := balanceOf(t, grantee.GetFormattedAddress())
+		resp, err := chain.BroadcastMessagesWithFeeGranter(testCfg.Ctx, grantee, granter, banktypes.NewMsgSend(granteeAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		// the grantee only pays the amount sent, the fees are paid by the granter.
+		require.True(t, before.Sub(sendAmount.AmountOf(denom)).Equal(balanceOf(t, grantee.GetFormattedAddress())))
+
+		require.NoError(t, chain.RevokeFeeAllowance(testCfg.Ctx, granter, grantee))
+		_, err = chain.BroadcastMessagesWithFeeGranter(testCfg.Ctx, grantee, granter, banktypes.NewMsgSend(granteeAddr, toAddr, sendAmount))
+		require.Error(t, err, "the fee allowance should have been revoked")
+	})
+
+	t.Run("fee payer", func(t *testing.T) {
+		before := balanceOf(t, grantee.GetFormattedAddress())
+		resp, err := chain.BroadcastMessagesWithFeePayer(testCfg.Ctx, grantee, granter, banktypes.NewMsgSend(granteeAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		require.True(t, before.Sub(sendAmount.AmountOf(denom)).Equal(balanceOf(t, grantee.GetFormattedAddress())))
+	})
+
+	t.Run("authz exec", func(t *testing.T) {
+		msgTypeURL := sdk.MsgTypeURL(&banktypes.MsgSend{})
+		resp, err := chain.GrantGenericAuthorization(testCfg.Ctx, granter, grantee, msgTypeURL)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		grants, err := chain.QueryGrants(testCfg.Ctx, granter, grantee, msgTypeURL)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+
+		before := balanceOf(t, granter.GetFormattedAddress())
+		resp, err = chain.ExecAuthorized(testCfg.Ctx, grantee, banktypes.NewMsgSend(granterAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		// the granter only pays the amount sent, the fees are paid by the grantee.
+		require.True(t, before.Sub(sendAmount.AmountOf(denom)).Equal(balanceOf(t, granter.GetFormattedAddress())))
+
+		resp, err = chain.RevokeAuthorization(testCfg.Ctx, granter, grantee, msgTypeURL)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		// the authorization is only checked on execution, after the tx has passed CheckTx.
+		resp, err = chain.ExecAuthorized(testCfg.Ctx, grantee, banktypes.NewMsgSend(granterAddr, toAddr, sendAmount))
+		require.True(t, err != nil || resp.Code != 0, "the authorization should have been revoked")
+	})
+
+	t.Run("failed authz grant", func(t *testing.T) {
+		expired := time.Now().Add(-time.Hour)
+		_, err := chain.GrantAuthorization(testCfg.Ctx, granter, grantee, authz.NewGenericAuthorization(sdk.MsgTypeURL(&banktypes.MsgSend{})), &expired)
+		require.Error(t, err, "a grant expiring in the past should be rejected")
+	})
+}
@@ -321,3 +321,32 @@ func (d *Deployer) readAddressFromDisk(ctx context.Context, meta ChainMetadata)
 func (d *Deployer) GetEVMWarpTokenAddress() (common.Address, error) {
 	return common.HexToAddress("0x345a583028762De4d733852c9D4f419077093A48"), nil
 }
+
+// GetEVMWarpTokenAddressForChain returns the address of the warp token deployed on the EVM chain, as recorded in the
+// warp route deployments of the registry.
+func (d *Deployer) GetEVMWarpTokenAddressForChain(ctx context.Context, chainName string) (common.Address, error) {
+	warpRoutesPath := path.Join(registryPath, "deployments", "warp_routes")
+	stdout, _, err := d.Exec(ctx, d.Logger, []string{"find", warpRoutesPath, "-name", "*-config.yaml"}, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("list warp route deployments: %w", err)
+	}
+
+	for _, file := range strings.Fields(string(stdout)) {
+		bz, err := d.ReadFile(ctx, strings.TrimPrefix(file, hyperlaneHomeDir+"/"))
+		if err != nil {
+			return common.Address{}, fmt.Errorf("read %s: %w", file, err)
+		}
+
+		var cfg WarpRouteConfig
+		if err := yaml.Unmarshal(bz, &cfg); err != nil {
+			return common.Address{}, fmt.Errorf("unmarshal yaml %s: %w", file, err)
+		}
+
+		for _, token := range cfg.Tokens {
+			if token.ChainName == chainName && common.IsHexAddress(token.AddressOrDenom) {
+				return common.HexToAddress(token.AddressOrDenom), nil
+			}
+		}
+	}
+	return common.Address{}, fmt.Errorf("no warp token deployed on %s", chainName)
+}
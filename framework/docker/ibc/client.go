@@ -0,0 +1,164 @@
+package ibc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/testutil/events"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/grpc"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+)
+
+// WaitForClientStatus waits until the client on the host chain has the status, e.g. ibcexported.Expired to wait
+// for a client which is no longer updated to outlive its trusting period.
+func WaitForClientStatus(ctx context.Context, host grpc.ClientConn, clientID string, status ibcexported.Status, timeout time.Duration) error {
+	var current ibcexported.Status
+	err := wait.ForCondition(ctx, timeout, time.Second, func() (bool, error) {
+		var err error
+		if current, err = query.ClientStatus(ctx, host, clientID); err != nil {
+			return false, err
+		}
+		return current == status, nil
+	})
+	if err != nil {
+		return fmt.Errorf("client %s is %s rather than %s: %w", clientID, current, status, err)
+	}
+	return nil
+}
+
+// NewDoubleSignMisbehaviour returns misbehaviour of the chain tracked by the client on the host chain made of the
+// headers committed at the same height by two nodes whose validators sign with the same key, e.g. a node of the
+// chain and a node of a fork of it: a chain with the same chain ID whose validator signs with the key of the
+// validator of the chain, as set by ChainNodeConfigBuilder.WithPrivValidatorKey. The headers are taken at the
+// latest height reached by both nodes, which has to be past the latest height of the client.
+func NewDoubleSignMisbehaviour(ctx context.Context, host grpc.ClientConn, clientID string, node, conflicting types.ChainNode) (*ibctm.Misbehaviour, error) {
+	clientState, err := query.TendermintClientState(ctx, host, clientID)
+	if err != nil {
+		return nil, err
+	}
+	trustedHeight := clientState.LatestHeight
+
+	rpcClient, err := node.GetRPCClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rpc client: %w", err)
+	}
+	conflictingRPCClient, err := conflicting.GetRPCClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rpc client of conflicting node: %w", err)
+	}
+
+	height, err := latestHeight(ctx, rpcClient)
+	if err != nil {
+		return nil, err
+	}
+	conflictingHeight, err := latestHeight(ctx, conflictingRPCClient)
+	if err != nil {
+		return nil, err
+	}
+	height = min(height, conflictingHeight)
+	if uint64(height) <= trustedHeight.RevisionHeight {
+		return nil, fmt.Errorf("nodes are at height %d, not past the trusted height %s of client %s", height, trustedHeight, clientID)
+	}
+
+	// the trusted validators are the next validators of the trusted consensus state.
+	trustedValidators, err := validatorSet(ctx, rpcClient, int64(trustedHeight.RevisionHeight)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	header1, err := committedHeader(ctx, rpcClient, height, trustedHeight, trustedValidators)
+	if err != nil {
+		return nil, err
+	}
+	header2, err := committedHeader(ctx, conflictingRPCClient, height, trustedHeight, trustedValidators)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(header1.SignedHeader.Commit.BlockID.Hash, header2.SignedHeader.Commit.BlockID.Hash) {
+		return nil, fmt.Errorf("nodes committed the same block at height %d", height)
+	}
+	return ibctm.NewMisbehaviour(clientID, header1, header2), nil
+}
+
+// SubmitMisbehaviour submits the misbehaviour to the client on the host chain, which freezes the client if the
+// misbehaviour is valid.
+func SubmitMisbehaviour(ctx context.Context, host types.Chain, signer *types.Wallet, clientID string, misbehaviour *ibctm.Misbehaviour) (sdk.TxResponse, error) {
+	msg, err := clienttypes.NewMsgUpdateClient(clientID, misbehaviour, signer.GetFormattedAddress())
+	if err != nil {
+		return sdk.TxResponse{}, fmt.Errorf("failed to create misbehaviour message: %w", err)
+	}
+	resp, err := host.BroadcastMessages(ctx, signer, msg)
+	if err != nil {
+		return sdk.TxResponse{}, fmt.Errorf("failed to broadcast misbehaviour: %w", err)
+	}
+	if err := events.TxError(resp); err != nil {
+		return resp, fmt.Errorf("misbehaviour submission failed: %w", err)
+	}
+	return resp, nil
+}
+
+// committedHeader returns the header committed by the node at the height as a header of a tendermint light client.
+func committedHeader(ctx context.Context, rpcClient rpcclient.Client, height int64, trustedHeight clienttypes.Height, trustedValidators *cmttypes.ValidatorSet) (*ibctm.Header, error) {
+	commit, err := rpcClient.Commit(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit at height %d: %w", height, err)
+	}
+	validators, err := validatorSet(ctx, rpcClient, height)
+	if err != nil {
+		return nil, err
+	}
+	return newTendermintHeader(commit.SignedHeader, validators, trustedHeight, trustedValidators)
+}
+
+// newTendermintHeader returns the signed header as a header of a tendermint light client.
+func newTendermintHeader(signed *cmttypes.SignedHeader, validators *cmttypes.ValidatorSet, trustedHeight clienttypes.Height, trustedValidators *cmttypes.ValidatorSet) (*ibctm.Header, error) {
+	validatorsProto, err := validators.ToProto()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert validator set: %w", err)
+	}
+	trustedValidatorsProto, err := trustedValidators.ToProto()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert trusted validator set: %w", err)
+	}
+	return &ibctm.Header{
+		SignedHeader:      signed.ToProto(),
+		ValidatorSet:      validatorsProto,
+		TrustedHeight:     trustedHeight,
+		TrustedValidators: trustedValidatorsProto,
+	}, nil
+}
+
+// validatorSet returns the validator set of the chain at the height.
+func validatorSet(ctx context.Context, rpcClient rpcclient.Client, height int64) (*cmttypes.ValidatorSet, error) {
+	var validators []*cmttypes.Validator
+	perPage := 100
+	for page := 1; ; page++ {
+		res, err := rpcClient.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get validators at height %d: %w", height, err)
+		}
+		validators = append(validators, res.Validators...)
+		if len(validators) >= res.Total || len(res.Validators) == 0 {
+			return cmttypes.NewValidatorSet(validators), nil
+		}
+	}
+}
+
+// latestHeight returns the latest height of the node.
+func latestHeight(ctx context.Context, rpcClient rpcclient.Client) (int64, error) {
+	status, err := rpcClient.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get status: %w", err)
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
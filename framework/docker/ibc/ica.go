@@ -0,0 +1,265 @@
+package ibc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/testutil/events"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/grpc"
+	"github.com/cosmos/gogoproto/proto"
+	icacontrollertypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// InterchainAccountOptions configures the registration of an interchain account.
+type InterchainAccountOptions struct {
+	// Order is the ordering of the interchain account channel, OrderOrdered if empty.
+	Order ChannelOrder
+	// Version is the ICS-27 metadata of the channel. If empty, the controller chain derives it from the connection.
+	Version string
+}
+
+// InterchainAccount is an ICS-27 interchain account on a host chain, controlled by an owner on the controller chain.
+type InterchainAccount struct {
+	// Owner is the address of the owner on the controller chain.
+	Owner string
+	// ConnectionID is the connection of the controller chain to the host chain.
+	ConnectionID string
+	// Channel is the interchain account channel, as seen from the controller chain.
+	Channel Channel
+	// Address is the address of the interchain account on the host chain, known once the channel is open.
+	Address string
+}
+
+// InterchainTxResult is the result of the execution of an interchain account transaction on the host chain.
+type InterchainTxResult struct {
+	// Sequence is the sequence of the packet carrying the transaction.
+	Sequence uint64
+	// HostTxHash is the hash of the host chain transaction which received the packet.
+	HostTxHash string
+	// MsgResponses are the responses of the messages if the transaction succeeded.
+	MsgResponses []*codectypes.Any
+	// Error is the error acknowledgement of the host chain if the transaction failed.
+	Error string
+}
+
+// Success returns true if the host chain executed the transaction successfully.
+func (r InterchainTxResult) Success() bool {
+	return r.Error == ""
+}
+
+// RegisterInterchainAccount registers an interchain account of the owner on the connection of the controller chain,
+// which initiates the handshake of its channel. A started relayer completes the handshake, see
+// WaitForInterchainAccount.
+func RegisterInterchainAccount(ctx context.Context, controller types.Chain, owner *types.Wallet, connectionID string, opts InterchainAccountOptions) (InterchainAccount, error) {
+	order := opts.Order
+	if order == "" {
+		order = OrderOrdered
+	}
+	portID, err := icatypes.NewControllerPortID(owner.GetFormattedAddress())
+	if err != nil {
+		return InterchainAccount{}, err
+	}
+
+	msg := icacontrollertypes.NewMsgRegisterInterchainAccount(connectionID, owner.GetFormattedAddress(), opts.Version, channelOrder(order))
+	resp, err := controller.BroadcastMessages(ctx, owner, msg)
+	if err != nil {
+		return InterchainAccount{}, fmt.Errorf("failed to broadcast interchain account registration: %w", err)
+	}
+	if err := events.TxError(resp); err != nil {
+		return InterchainAccount{}, fmt.Errorf("interchain account registration failed: %w", err)
+	}
+
+	channelID, err := events.AttributeValue(resp.Events, channeltypes.EventTypeChannelOpenInit, channeltypes.AttributeKeyChannelID)
+	if err != nil {
+		return InterchainAccount{}, err
+	}
+
+	return InterchainAccount{
+		Owner:        owner.GetFormattedAddress(),
+		ConnectionID: connectionID,
+		Channel: Channel{
+			ChannelID:        channelID,
+			PortID:           portID,
+			CounterpartyPort: icatypes.HostPortID,
+			ConnectionID:     connectionID,
+			State:            channeltypes.INIT.String(),
+			Order:            order,
+			Version:          opts.Version,
+		},
+	}, nil
+}
+
+// WaitForInterchainAccount waits until the handshake of the channel of the interchain account is completed and
+// returns the account with its open channel and its address on the host chain.
+func WaitForInterchainAccount(ctx context.Context, controller grpc.ClientConn, account InterchainAccount, timeout time.Duration) (InterchainAccount, error) {
+	err := wait.ForCondition(ctx, timeout, time.Second, func() (bool, error) {
+		channel, err := query.Channel(ctx, controller, account.Channel.PortID, account.Channel.ChannelID)
+		if err != nil {
+			return false, err
+		}
+		if channel.State != channeltypes.OPEN {
+			return false, nil
+		}
+		account.Channel.CounterpartyID = channel.Counterparty.ChannelId
+		account.Channel.State = channel.State.String()
+		account.Channel.Version = channel.Version
+		return true, nil
+	})
+	if err != nil {
+		return InterchainAccount{}, fmt.Errorf("channel %s of interchain account of %s not opened: %w", account.Channel.ChannelID, account.Owner, err)
+	}
+
+	connection, err := query.Connection(ctx, controller, account.ConnectionID)
+	if err != nil {
+		return InterchainAccount{}, err
+	}
+	account.Channel.CounterpartyConnectionID = connection.Counterparty.ConnectionId
+
+	account.Address, err = query.InterchainAccount(ctx, controller, account.Owner, account.ConnectionID)
+	if err != nil {
+		return InterchainAccount{}, err
+	}
+	return account, nil
+}
+
+// InterchainAccountChannelOptions returns the options to open a new channel for the interchain account with
+// Relayer.CreateChannel, e.g. after its ordered channel was closed by a packet timeout. The connection is the
+// connection of the account, as seen from the controller chain.
+func InterchainAccountChannelOptions(account InterchainAccount, connection Connection) CreateChannelOptions {
+	return CreateChannelOptions{
+		SourcePortName: account.Channel.PortID,
+		DestPortName:   icatypes.HostPortID,
+		Order:          account.Channel.Order,
+		Version:        icatypes.NewDefaultMetadataString(connection.ConnectionID, connection.CounterpartyID),
+	}
+}
+
+// SendInterchainTx sends the messages to be executed by the interchain account on the host chain, and returns the
+// sequence of the packet. The packet times out if it is not received on the host chain within the timeout.
+func SendInterchainTx(ctx context.Context, controller types.Chain, owner *types.Wallet, account InterchainAccount, timeout time.Duration, msgs ...sdk.Msg) (uint64, error) {
+	protoMsgs := make([]proto.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		protoMsgs = append(protoMsgs, msg)
+	}
+	// the messages are packed into anys with their type URLs, so no interfaces have to be registered.
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	data, err := icatypes.SerializeCosmosTx(cdc, protoMsgs, icatypes.EncodingProtobuf)
+	if err != nil {
+		return 0, fmt.Errorf("failed to serialize interchain account tx: %w", err)
+	}
+
+	packetData := icatypes.InterchainAccountPacketData{
+		Type: icatypes.EXECUTE_TX,
+		Data: data,
+	}
+	msg := icacontrollertypes.NewMsgSendTx(account.Owner, account.ConnectionID, uint64(timeout.Nanoseconds()), packetData)
+	resp, err := controller.BroadcastMessages(ctx, owner, msg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to broadcast interchain account tx: %w", err)
+	}
+	if err := events.TxError(resp); err != nil {
+		return 0, fmt.Errorf("interchain account tx failed: %w", err)
+	}
+
+	value, err := events.AttributeValue(resp.Events, channeltypes.EventTypeSendPacket, channeltypes.AttributeKeySequence)
+	if err != nil {
+		return 0, err
+	}
+	sequence, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse packet sequence %q: %w", value, err)
+	}
+	return sequence, nil
+}
+
+// WaitForInterchainTxResult waits until the host chain received the packet of the interchain account with the
+// sequence, and returns the result of the execution of its transaction.
+func WaitForInterchainTxResult(ctx context.Context, host types.Chain, account InterchainAccount, sequence uint64, timeout time.Duration) (InterchainTxResult, error) {
+	nodes := host.GetNodes()
+	if len(nodes) == 0 {
+		return InterchainTxResult{}, fmt.Errorf("chain %s has no nodes", host.GetChainID())
+	}
+	rpcClient, err := nodes[0].GetRPCClient()
+	if err != nil {
+		return InterchainTxResult{}, fmt.Errorf("failed to get rpc client: %w", err)
+	}
+
+	seq := strconv.FormatUint(sequence, 10)
+	txQuery := fmt.Sprintf("%[1]s.%[2]s='%[3]s' AND %[1]s.%[4]s='%[5]s'",
+		channeltypes.EventTypeWriteAck, channeltypes.AttributeKeyDstChannel, account.Channel.CounterpartyID,
+		channeltypes.AttributeKeySequence, seq)
+
+	result := InterchainTxResult{Sequence: sequence}
+	var ackHex string
+	err = wait.ForCondition(ctx, timeout, time.Second, func() (bool, error) {
+		res, err := rpcClient.TxSearch(ctx, txQuery, false, nil, nil, "")
+		if err != nil {
+			return false, err
+		}
+		for _, tx := range res.Txs {
+			ack, ok := events.First(tx.TxResult.Events, channeltypes.EventTypeWriteAck,
+				events.Attr(channeltypes.AttributeKeyDstChannel, account.Channel.CounterpartyID),
+				events.Attr(channeltypes.AttributeKeySequence, seq))
+			if !ok {
+				continue
+			}
+			if ackHex, ok = events.AttributeOf(ack, channeltypes.AttributeKeyAckHex); ok {
+				result.HostTxHash = tx.Hash.String()
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return InterchainTxResult{}, fmt.Errorf("packet %d of interchain account of %s not received: %w", sequence, account.Owner, err)
+	}
+
+	ackBz, err := hex.DecodeString(ackHex)
+	if err != nil {
+		return InterchainTxResult{}, fmt.Errorf("failed to decode acknowledgement: %w", err)
+	}
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(ackBz, &ack); err != nil {
+		return InterchainTxResult{}, fmt.Errorf("failed to unmarshal acknowledgement: %w", err)
+	}
+	if !ack.Success() {
+		result.Error = ack.GetError()
+		return result, nil
+	}
+
+	var txMsgData sdk.TxMsgData
+	if err := proto.Unmarshal(ack.GetResult(), &txMsgData); err != nil {
+		return InterchainTxResult{}, fmt.Errorf("failed to unmarshal interchain account tx result: %w", err)
+	}
+	result.MsgResponses = txMsgData.MsgResponses
+	return result, nil
+}
+
+// ExecuteInterchainTx sends the messages to be executed by the interchain account on the host chain and waits for
+// the result of their execution. The relayer has to be started.
+func ExecuteInterchainTx(ctx context.Context, controller, host types.Chain, owner *types.Wallet, account InterchainAccount, timeout time.Duration, msgs ...sdk.Msg) (InterchainTxResult, error) {
+	sequence, err := SendInterchainTx(ctx, controller, owner, account, timeout, msgs...)
+	if err != nil {
+		return InterchainTxResult{}, err
+	}
+	return WaitForInterchainTxResult(ctx, host, account, sequence, timeout)
+}
+
+// channelOrder returns the IBC ordering of the channel order.
+func channelOrder(order ChannelOrder) channeltypes.Order {
+	if order == OrderUnordered {
+		return channeltypes.UNORDERED
+	}
+	return channeltypes.ORDERED
+}
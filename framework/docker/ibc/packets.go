@@ -0,0 +1,104 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/cosmos/gogoproto/grpc"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// PendingPackets are the packets sent on a channel which are still in flight.
+type PendingPackets struct {
+	// Unreceived are the sequences of the packets which have not been received on the counterparty channel,
+	// including packets which timed out but whose timeout has not been relayed yet.
+	Unreceived []uint64
+	// UnreceivedAcks are the sequences of the packets received on the counterparty channel whose
+	// acknowledgements have not been relayed back yet.
+	UnreceivedAcks []uint64
+}
+
+// Empty returns true if no packet or acknowledgement is pending.
+func (p PendingPackets) Empty() bool {
+	return len(p.Unreceived) == 0 && len(p.UnreceivedAcks) == 0
+}
+
+// Counterparty returns the channel as seen from the counterparty chain.
+func (c Channel) Counterparty() Channel {
+	return Channel{
+		ChannelID:        c.CounterpartyID,
+		CounterpartyID:   c.ChannelID,
+		PortID:           c.CounterpartyPort,
+		CounterpartyPort: c.PortID,
+		State:            c.State,
+		Order:            c.Order,
+		Version:          c.Version,
+
+		ConnectionID:             c.CounterpartyConnectionID,
+		CounterpartyConnectionID: c.ConnectionID,
+	}
+}
+
+// QueryPendingPackets queries the packets sent on the channel of the source chain which have not been received
+// by the destination chain, and those received whose acknowledgements have not been relayed back.
+// The channel is given as seen from the source chain.
+func QueryPendingPackets(ctx context.Context, src, dst grpc.ClientConn, channel Channel) (PendingPackets, error) {
+	var pending PendingPackets
+
+	commitments, err := query.PacketCommitments(ctx, src, channel.PortID, channel.ChannelID)
+	if err != nil {
+		return PendingPackets{}, err
+	}
+	if sequences := packetSequences(commitments); len(sequences) > 0 {
+		pending.Unreceived, err = query.UnreceivedPackets(ctx, dst, channel.CounterpartyPort, channel.CounterpartyID, sequences)
+		if err != nil {
+			return PendingPackets{}, err
+		}
+	}
+
+	acks, err := query.PacketAcknowledgements(ctx, dst, channel.CounterpartyPort, channel.CounterpartyID)
+	if err != nil {
+		return PendingPackets{}, err
+	}
+	if sequences := packetSequences(acks); len(sequences) > 0 {
+		pending.UnreceivedAcks, err = query.UnreceivedAcks(ctx, src, channel.PortID, channel.ChannelID, sequences)
+		if err != nil {
+			return PendingPackets{}, err
+		}
+	}
+
+	return pending, nil
+}
+
+// WaitForFlushed waits until no packet or acknowledgement is pending on the channel in either direction, e.g.
+// after Relayer.Flush or while the relayer is started. The channel is given as seen from chain A.
+func WaitForFlushed(ctx context.Context, chainA, chainB grpc.ClientConn, channel Channel, timeout time.Duration) error {
+	var pendingAB, pendingBA PendingPackets
+	err := wait.ForCondition(ctx, timeout, time.Second, func() (bool, error) {
+		var err error
+		if pendingAB, err = QueryPendingPackets(ctx, chainA, chainB, channel); err != nil {
+			return false, err
+		}
+		if pendingBA, err = QueryPendingPackets(ctx, chainB, chainA, channel.Counterparty()); err != nil {
+			return false, err
+		}
+		return pendingAB.Empty() && pendingBA.Empty(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("channel %s not flushed, pending from chain A: %+v, pending from chain B: %+v: %w",
+			channel.ChannelID, pendingAB, pendingBA, err)
+	}
+	return nil
+}
+
+// packetSequences returns the sequences of the packet states.
+func packetSequences(states []*channeltypes.PacketState) []uint64 {
+	sequences := make([]uint64, 0, len(states))
+	for _, state := range states {
+		sequences = append(sequences, state.Sequence)
+	}
+	return sequences
+}
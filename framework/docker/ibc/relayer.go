@@ -0,0 +1,42 @@
+package ibc
+
+import (
+	"context"
+
+	"github.com/celestiaorg/tastora/framework/types"
+)
+
+// Relayer relays IBC packets between chains. It is implemented by the relayers of the relayer package so that
+// tests can run against any of them.
+type Relayer interface {
+	// Init writes the configuration of the relayer for the chains, and creates and funds a relayer wallet on
+	// each of them.
+	Init(ctx context.Context, chains []types.Chain) error
+
+	// CreateClients creates an IBC client of each chain on the other chain.
+	CreateClients(ctx context.Context, chainA, chainB types.Chain) error
+	// CreateClient creates an IBC client of the reference chain on the host chain which is not used by the
+	// connections of the relayer, e.g. to substitute an expired or frozen client, and returns its ID.
+	CreateClient(ctx context.Context, host, reference types.Chain, opts CreateClientOptions) (string, error)
+	// CreateConnections creates a connection between the chains on top of their clients, and returns it as
+	// seen from chainA.
+	CreateConnections(ctx context.Context, chainA, chainB types.Chain) (Connection, error)
+	// CreateChannel creates a channel on the connection, and returns it as seen from chainA.
+	CreateChannel(ctx context.Context, chainA types.Chain, connection Connection, opts CreateChannelOptions) (Channel, error)
+
+	// Start starts relaying packets in the background, args are appended to the start command of the relayer.
+	Start(ctx context.Context, args ...string) error
+	// Stop stops relaying packets.
+	Stop(ctx context.Context) error
+	// Flush relays the packets and acknowledgements pending on the channel of chainA, in both directions.
+	// It can be used whether or not the relayer is started.
+	Flush(ctx context.Context, chainA types.Chain, channel Channel) error
+
+	// AddKey creates a key for the chain from a new mnemonic and returns its address.
+	AddKey(ctx context.Context, chain types.Chain, keyName string) (string, error)
+	// RestoreKey imports the key of the mnemonic for the chain and returns its address.
+	RestoreKey(ctx context.Context, chain types.Chain, keyName, mnemonic string) (string, error)
+	// UseKey makes the relayer sign its transactions on the chain with the key. A started relayer has to be
+	// restarted for the key to be used.
+	UseKey(ctx context.Context, chain types.Chain, keyName string) error
+}
@@ -0,0 +1,434 @@
+package relayer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/celestiaorg/tastora/framework/docker/container"
+	"github.com/celestiaorg/tastora/framework/docker/ibc"
+	"github.com/celestiaorg/tastora/framework/docker/internal"
+	"github.com/celestiaorg/tastora/framework/types"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	goRelayerDefaultImage   = "ghcr.io/cosmos/relayer"
+	goRelayerDefaultVersion = "v2.5.2"
+	goRelayerDefaultUIDGID  = "100:1000"
+	goRelayerHomeDir        = "/home/relayer"
+	goRelayerConfigPath     = "config/config.yaml"
+)
+
+var _ ibc.Relayer = &GoRelayer{}
+
+// GoRelayer implements the IBC relayer interface using the cosmos Go relayer (rly).
+// Every pair of chains is relayed over a path named after their chain IDs, which is created with the clients.
+type GoRelayer struct {
+	*container.Node
+	// started indicates if the relayer has been started or not.
+	started bool
+	// configOptions modify the chain configurations generated during Init.
+	configOptions []GoRelayerConfigOption
+}
+
+// NewGoRelayer creates a new Go relayer instance. The options modify the chain configurations generated by Init.
+func NewGoRelayer(ctx context.Context, dockerClient types.TastoraDockerClient, testName, networkID string, index int, logger *zap.Logger, opts ...GoRelayerConfigOption) (*GoRelayer, error) {
+	image := container.Image{
+		Repository: goRelayerDefaultImage,
+		Version:    goRelayerDefaultVersion,
+		UIDGID:     goRelayerDefaultUIDGID,
+	}
+
+	node := container.NewNode(
+		networkID,
+		dockerClient,
+		testName,
+		image,
+		goRelayerHomeDir,
+		index,
+		GoRelayerRelayer,
+		logger,
+	)
+
+	rly := &GoRelayer{
+		Node:          node,
+		configOptions: opts,
+	}
+
+	lifecycle := container.NewLifecycle(logger, dockerClient, rly.Name())
+	rly.SetContainerLifecycle(lifecycle)
+
+	if err := rly.CreateAndSetupVolume(ctx, rly.Name()); err != nil {
+		return nil, err
+	}
+
+	return rly, nil
+}
+
+// Name returns the hostname of the docker container.
+func (r *GoRelayer) Name() string {
+	return fmt.Sprintf("%s-%d-rly", internal.SanitizeDockerResourceName(r.TestName), r.Index)
+}
+
+// Start starts relaying packets on all paths.
+func (r *GoRelayer) Start(ctx context.Context, args ...string) error {
+	if r.started {
+		return fmt.Errorf("already started")
+	}
+
+	cmd := append([]string{"rly", "start", "--home", r.HomeDir()}, args...)
+	err := r.CreateContainer(ctx, r.TestName, r.NetworkID, r.Image, nil, "", r.Bind(), nil, r.Name(), cmd, nil, []string{})
+	if err != nil {
+		return fmt.Errorf("failed to create rly container: %w", err)
+	}
+
+	if err := r.StartContainer(ctx); err != nil {
+		return fmt.Errorf("failed to start rly container: %w", err)
+	}
+
+	r.started = true
+	return nil
+}
+
+// Stop stops the Go relayer.
+func (r *GoRelayer) Stop(ctx context.Context) error {
+	if !r.started {
+		return fmt.Errorf("not started")
+	}
+
+	if err := r.StopContainer(ctx); err != nil {
+		return err
+	}
+
+	if err := r.RemoveContainer(ctx); err != nil {
+		return err
+	}
+
+	r.started = false
+	return nil
+}
+
+// Init initializes the relayer configuration, adds the chains to it, and creates and funds wallets on them.
+func (r *GoRelayer) Init(ctx context.Context, chains []types.Chain) error {
+	if _, err := r.exec(ctx, "config", "init"); err != nil {
+		return fmt.Errorf("failed to init rly config: %w", err)
+	}
+
+	for _, chain := range chains {
+		if err := r.addChain(ctx, chain); err != nil {
+			return fmt.Errorf("failed to add chain %s: %w", chain.GetChainID(), err)
+		}
+	}
+
+	// NOTE: it is not possible to do this operation in parallel as it potentially modifies the global sdk
+	// config which could interfere with sdk functions for the other chains if the bech32 prefix is different.
+	for _, chain := range chains {
+		keyName := fmt.Sprintf("relayer-%s", chain.GetChainID())
+		address, err := r.AddKey(ctx, chain, keyName)
+		if err != nil {
+			return fmt.Errorf("failed to create relayer key for chain %s: %w", chain.GetChainID(), err)
+		}
+		if err := fundRelayerAddress(ctx, chain, address); err != nil {
+			return fmt.Errorf("failed to fund relayer address on chain %s: %w", chain.GetChainID(), err)
+		}
+	}
+	return nil
+}
+
+// addChain writes the configuration of the chain and adds it to the relayer.
+func (r *GoRelayer) addChain(ctx context.Context, chain types.Chain) error {
+	chainConfig := NewGoRelayerChainConfig(chain.GetRelayerConfig())
+	for _, opt := range r.configOptions {
+		opt(&chainConfig)
+	}
+
+	configJSON, err := json.Marshal(chainConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chain config: %w", err)
+	}
+
+	configFile := fmt.Sprintf("%s.json", chain.GetChainID())
+	if err := r.WriteFile(ctx, configFile, configJSON); err != nil {
+		return fmt.Errorf("failed to write chain config: %w", err)
+	}
+
+	_, err = r.exec(ctx, "chains", "add", "--file", path.Join(r.HomeDir(), configFile), chain.GetChainID())
+	return err
+}
+
+// CreateClients creates the path between the chains and an IBC client of each chain on the other.
+func (r *GoRelayer) CreateClients(ctx context.Context, chainA, chainB types.Chain) error {
+	pathName := goRelayerPathName(chainA, chainB)
+	if _, err := r.exec(ctx, "paths", "new", chainA.GetChainID(), chainB.GetChainID(), pathName); err != nil {
+		return fmt.Errorf("failed to create path %s: %w", pathName, err)
+	}
+
+	_, err := r.exec(ctx, "transact", "clients", pathName)
+	return err
+}
+
+// CreateClient creates an IBC client of the reference chain on the host chain and returns its ID. The client is
+// created on a path of its own so that the path created by CreateClients keeps its clients.
+func (r *GoRelayer) CreateClient(ctx context.Context, host, reference types.Chain, opts ibc.CreateClientOptions) (string, error) {
+	config, err := r.readConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	pathName := fmt.Sprintf("%s-client-%d", goRelayerPathName(host, reference), len(config.Paths))
+	if _, err := r.exec(ctx, "paths", "new", host.GetChainID(), reference.GetChainID(), pathName); err != nil {
+		return "", fmt.Errorf("failed to create path %s: %w", pathName, err)
+	}
+
+	cmd := []string{"tx", "client", host.GetChainID(), reference.GetChainID(), pathName, "--override"}
+	if opts.TrustingPeriod != "" {
+		cmd = append(cmd, "--client-tp", opts.TrustingPeriod)
+	}
+	if _, err := r.exec(ctx, cmd...); err != nil {
+		return "", err
+	}
+
+	if config, err = r.readConfig(ctx); err != nil {
+		return "", err
+	}
+	clientID := config.Paths[pathName].Src.ClientID
+	if clientID == "" {
+		return "", fmt.Errorf("no client ID found for path %s", pathName)
+	}
+	return clientID, nil
+}
+
+// CreateConnections creates a connection between the chains on the path created by CreateClients.
+func (r *GoRelayer) CreateConnections(ctx context.Context, chainA, chainB types.Chain) (ibc.Connection, error) {
+	pathName := goRelayerPathName(chainA, chainB)
+	if _, err := r.exec(ctx, "transact", "connection", pathName); err != nil {
+		return ibc.Connection{}, err
+	}
+
+	config, err := r.readConfig(ctx)
+	if err != nil {
+		return ibc.Connection{}, err
+	}
+	p, ok := config.Paths[pathName]
+	if !ok {
+		return ibc.Connection{}, fmt.Errorf("path %s not found in rly config", pathName)
+	}
+	if p.Src.ConnectionID == "" {
+		return ibc.Connection{}, fmt.Errorf("no connection ID found for path %s", pathName)
+	}
+
+	return ibc.Connection{
+		ConnectionID:         p.Src.ConnectionID,
+		CounterpartyID:       p.Dst.ConnectionID,
+		ClientID:             p.Src.ClientID,
+		CounterpartyClientID: p.Dst.ClientID,
+		State:                "OPEN",
+	}, nil
+}
+
+// CreateChannel creates a channel on the path of the connection.
+func (r *GoRelayer) CreateChannel(ctx context.Context, chainA types.Chain, connection ibc.Connection, opts ibc.CreateChannelOptions) (ibc.Channel, error) {
+	if connection.ConnectionID == "" {
+		return ibc.Channel{}, fmt.Errorf("invalid connection: connection ID is empty")
+	}
+
+	pathName, err := r.pathOfConnection(ctx, chainA, connection.ConnectionID)
+	if err != nil {
+		return ibc.Channel{}, err
+	}
+
+	cmd := []string{
+		"transact", "channel", pathName,
+		"--src-port", opts.SourcePortName,
+		"--dst-port", opts.DestPortName,
+		"--order", string(opts.Order),
+		"--version", opts.Version,
+	}
+	if _, err := r.exec(ctx, cmd...); err != nil {
+		return ibc.Channel{}, err
+	}
+
+	channel, err := r.latestChannel(ctx, chainA, connection.ConnectionID, opts.SourcePortName)
+	if err != nil {
+		return ibc.Channel{}, fmt.Errorf("failed to query created channel: %w", err)
+	}
+
+	return ibc.Channel{
+		ChannelID:        channel.ChannelID,
+		CounterpartyID:   channel.Counterparty.ChannelID,
+		PortID:           opts.SourcePortName,
+		CounterpartyPort: opts.DestPortName,
+		Order:            opts.Order,
+		Version:          opts.Version,
+		State:            "OPEN",
+
+		ConnectionID:             connection.ConnectionID,
+		CounterpartyConnectionID: connection.CounterpartyID,
+	}, nil
+}
+
+// Flush relays the packets and acknowledgements pending on the channel of chainA, in both directions.
+// The channel is flushed on the path of its connection, which must be set.
+func (r *GoRelayer) Flush(ctx context.Context, chainA types.Chain, channel ibc.Channel) error {
+	if channel.ConnectionID == "" {
+		return fmt.Errorf("invalid channel %s: connection ID is empty", channel.ChannelID)
+	}
+
+	config, err := r.readConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	chainID := chainA.GetChainID()
+	for name, p := range config.Paths {
+		// the channel is identified by its ID on the source chain of the path.
+		var srcChannelID string
+		switch {
+		case p.Src.ChainID == chainID && p.Src.ConnectionID == channel.ConnectionID:
+			srcChannelID = channel.ChannelID
+		case p.Dst.ChainID == chainID && p.Dst.ConnectionID == channel.ConnectionID:
+			srcChannelID = channel.CounterpartyID
+		default:
+			continue
+		}
+		if _, err := r.exec(ctx, "transact", "flush", name, srcChannelID); err != nil {
+			return fmt.Errorf("failed to flush channel %s: %w", channel.ChannelID, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("no path found for connection %s on chain %s", channel.ConnectionID, chainID)
+}
+
+// AddKey creates a key for the chain from a new mnemonic and returns its address.
+func (r *GoRelayer) AddKey(ctx context.Context, chain types.Chain, keyName string) (string, error) {
+	mnemonic, err := generateMnemonic()
+	if err != nil {
+		return "", err
+	}
+	return r.RestoreKey(ctx, chain, keyName, mnemonic)
+}
+
+// RestoreKey imports the key of the mnemonic for the chain and returns its address.
+func (r *GoRelayer) RestoreKey(ctx context.Context, chain types.Chain, keyName, mnemonic string) (string, error) {
+	stdout, err := r.exec(ctx, "keys", "restore", chain.GetChainID(), keyName, mnemonic)
+	if err != nil {
+		return "", fmt.Errorf("failed to restore key %s for chain %s: %w", keyName, chain.GetChainID(), err)
+	}
+
+	address, err := extractAddress(string(stdout))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse address from key restore output: %w", err)
+	}
+
+	r.Logger.Info("Created relayer key",
+		zap.String("chain_id", chain.GetChainID()),
+		zap.String("key_name", keyName),
+		zap.String("address", address))
+
+	return address, nil
+}
+
+// UseKey sets the key the relayer signs its transactions on the chain with.
+func (r *GoRelayer) UseKey(ctx context.Context, chain types.Chain, keyName string) error {
+	_, err := r.exec(ctx, "keys", "use", chain.GetChainID(), keyName)
+	return err
+}
+
+// exec runs the rly command with the relayer's home directory and returns its stdout.
+func (r *GoRelayer) exec(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := append([]string{"rly"}, args...)
+	cmd = append(cmd, "--home", r.HomeDir())
+	stdout, stderr, err := r.Exec(ctx, r.Logger, cmd, nil)
+	if err != nil {
+		return nil, fmt.Errorf("rly %s failed: %w: %s", strings.Join(args[:min(2, len(args))], " "), err, stderr)
+	}
+	return stdout, nil
+}
+
+// readConfig reads the paths of the relayer from its configuration file.
+func (r *GoRelayer) readConfig(ctx context.Context) (goRelayerConfigFile, error) {
+	fileBz, err := r.ReadFile(ctx, goRelayerConfigPath)
+	if err != nil {
+		return goRelayerConfigFile{}, fmt.Errorf("failed to read rly config: %w", err)
+	}
+
+	var config goRelayerConfigFile
+	if err := yaml.Unmarshal(fileBz, &config); err != nil {
+		return goRelayerConfigFile{}, fmt.Errorf("failed to unmarshal rly config: %w", err)
+	}
+	return config, nil
+}
+
+// pathOfConnection returns the name of the path whose source is chainA and connection.
+func (r *GoRelayer) pathOfConnection(ctx context.Context, chainA types.Chain, connectionID string) (string, error) {
+	config, err := r.readConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	for name, p := range config.Paths {
+		if p.Src.ChainID == chainA.GetChainID() && p.Src.ConnectionID == connectionID {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no path found for connection %s on chain %s", connectionID, chainA.GetChainID())
+}
+
+// latestChannel returns the channel of the port with the highest ID on the connection of the chain.
+func (r *GoRelayer) latestChannel(ctx context.Context, chain types.Chain, connectionID, portID string) (goRelayerChannel, error) {
+	stdout, err := r.exec(ctx, "query", "connection-channels", chain.GetChainID(), connectionID)
+	if err != nil {
+		return goRelayerChannel{}, err
+	}
+	return parseLatestChannel(stdout, portID)
+}
+
+// parseLatestChannel returns the channel of the port with the highest ID from the output of
+// `rly query connection-channels`, which prints one channel per line.
+func parseLatestChannel(output []byte, portID string) (goRelayerChannel, error) {
+	var (
+		latest    goRelayerChannel
+		latestSeq = -1
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		var channel goRelayerChannel
+		if err := json.Unmarshal(line, &channel); err != nil {
+			return goRelayerChannel{}, fmt.Errorf("failed to unmarshal channel: %w", err)
+		}
+		if channel.PortID != portID {
+			continue
+		}
+		seq, err := strconv.Atoi(strings.TrimPrefix(channel.ChannelID, "channel-"))
+		if err != nil {
+			return goRelayerChannel{}, fmt.Errorf("unexpected channel ID %q: %w", channel.ChannelID, err)
+		}
+		if seq > latestSeq {
+			latest, latestSeq = channel, seq
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return goRelayerChannel{}, err
+	}
+
+	if latestSeq < 0 {
+		return goRelayerChannel{}, fmt.Errorf("no channel found for port %s", portID)
+	}
+	return latest, nil
+}
+
+// goRelayerPathName returns the name of the path between the chains.
+func goRelayerPathName(chainA, chainB types.Chain) string {
+	return fmt.Sprintf("%s-%s", chainA.GetChainID(), chainB.GetChainID())
+}
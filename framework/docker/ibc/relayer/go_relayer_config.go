@@ -0,0 +1,93 @@
+package relayer
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/tastora/framework/types"
+)
+
+// GoRelayerConfigOption defines a function type for configuring the GoRelayerChainConfig of every chain.
+type GoRelayerConfigOption func(*GoRelayerChainConfig)
+
+// GoRelayerChainConfig represents the configuration of a chain added to the Go relayer with `rly chains add`.
+type GoRelayerChainConfig struct {
+	Type  string                    `json:"type"`
+	Value GoRelayerCosmosChainValue `json:"value"`
+}
+
+// GoRelayerCosmosChainValue contains the settings of a cosmos chain.
+type GoRelayerCosmosChainValue struct {
+	Key            string  `json:"key"`
+	ChainID        string  `json:"chain-id"`
+	RPCAddr        string  `json:"rpc-addr"`
+	AccountPrefix  string  `json:"account-prefix"`
+	KeyringBackend string  `json:"keyring-backend"`
+	GasAdjustment  float64 `json:"gas-adjustment"`
+	GasPrices      string  `json:"gas-prices"`
+	MinGasAmount   uint64  `json:"min-gas-amount"`
+	MaxGasAmount   uint64  `json:"max-gas-amount"`
+	Debug          bool    `json:"debug"`
+	Timeout        string  `json:"timeout"`
+	BlockTimeout   string  `json:"block-timeout"`
+	OutputFormat   string  `json:"output-format"`
+	SignMode       string  `json:"sign-mode"`
+	CoinType       int     `json:"coin-type"`
+	TrustingPeriod string  `json:"trusting-period"`
+}
+
+// NewGoRelayerChainConfig creates the Go relayer configuration of a chain from its relayer config.
+func NewGoRelayerChainConfig(chainCfg types.ChainRelayerConfig) GoRelayerChainConfig {
+	trustingPeriod := "336h"
+	if chainCfg.TrustingPeriod != "" {
+		trustingPeriod = chainCfg.TrustingPeriod
+	}
+	return GoRelayerChainConfig{
+		Type: "cosmos",
+		Value: GoRelayerCosmosChainValue{
+			Key:            fmt.Sprintf("relayer-%s", chainCfg.ChainID),
+			ChainID:        chainCfg.ChainID,
+			RPCAddr:        chainCfg.RPCAddress,
+			AccountPrefix:  chainCfg.Bech32Prefix,
+			KeyringBackend: "test",
+			GasAdjustment:  1.3,
+			GasPrices:      chainCfg.GasPrices,
+			Debug:          false,
+			Timeout:        "20s",
+			OutputFormat:   "json",
+			SignMode:       "direct",
+			CoinType:       118,
+			TrustingPeriod: trustingPeriod,
+		},
+	}
+}
+
+// goRelayerConfigFile is the part of the Go relayer's config.yaml describing its paths.
+type goRelayerConfigFile struct {
+	Paths map[string]goRelayerPath `yaml:"paths"`
+}
+
+// goRelayerPath is a path between two chains, its ends are filled in as clients and connections are created.
+type goRelayerPath struct {
+	Src goRelayerPathEnd `yaml:"src"`
+	Dst goRelayerPathEnd `yaml:"dst"`
+}
+
+// goRelayerPathEnd is the client and connection of one chain of a path.
+type goRelayerPathEnd struct {
+	ChainID      string `yaml:"chain-id"`
+	ClientID     string `yaml:"client-id"`
+	ConnectionID string `yaml:"connection-id"`
+}
+
+// goRelayerChannel is a channel as printed by `rly query connection-channels`.
+type goRelayerChannel struct {
+	State        string `json:"state"`
+	Ordering     string `json:"ordering"`
+	Counterparty struct {
+		PortID    string `json:"port_id"`
+		ChannelID string `json:"channel_id"`
+	} `json:"counterparty"`
+	Version   string `json:"version"`
+	PortID    string `json:"port_id"`
+	ChannelID string `json:"channel_id"`
+}
@@ -6,18 +6,12 @@ import (
 	"fmt"
 	"github.com/BurntSushi/toml"
 	"path"
-	"regexp"
 	"strings"
 
-	sdkmath "cosmossdk.io/math"
 	"github.com/celestiaorg/tastora/framework/docker/container"
 	"github.com/celestiaorg/tastora/framework/docker/ibc"
 	"github.com/celestiaorg/tastora/framework/docker/internal"
-	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
 	"github.com/celestiaorg/tastora/framework/types"
-	sdk "github.com/cosmos/cosmos-sdk/types"
-	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
-	"github.com/cosmos/go-bip39"
 	"go.uber.org/zap"
 )
 
@@ -26,17 +20,22 @@ const (
 	hermesDefaultVersion = "1.13.1"
 	hermesDefaultUIDGID  = "2000:2000"
 	hermesHomeDir        = "/home/hermes"
+	hermesConfigPath     = ".hermes/config.toml"
 )
 
+var _ ibc.Relayer = &Hermes{}
+
 // Hermes implements the IBC relayer interface using Hermes.
 type Hermes struct {
 	*container.Node
 	// started indicates if the relayer has been started or not.
 	started bool
+	// configOptions modify the configuration generated during Init.
+	configOptions []ConfigOption
 }
 
-// NewHermes creates a new Hermes relayer instance.
-func NewHermes(ctx context.Context, dockerClient types.TastoraDockerClient, testName, networkID string, index int, logger *zap.Logger) (*Hermes, error) {
+// NewHermes creates a new Hermes relayer instance. The options modify the configuration generated by Init.
+func NewHermes(ctx context.Context, dockerClient types.TastoraDockerClient, testName, networkID string, index int, logger *zap.Logger, opts ...ConfigOption) (*Hermes, error) {
 	image := container.Image{
 		Repository: hermesDefaultImage,
 		Version:    hermesDefaultVersion,
@@ -55,7 +54,8 @@ func NewHermes(ctx context.Context, dockerClient types.TastoraDockerClient, test
 	)
 
 	hermes := &Hermes{
-		Node: node,
+		Node:          node,
+		configOptions: opts,
 	}
 
 	lifecycle := container.NewLifecycle(logger, dockerClient, hermes.Name())
@@ -118,8 +118,8 @@ func (h *Hermes) Stop(ctx context.Context) error {
 }
 
 // Init initializes and validates the relayer configuration, and creates and funds wallets on the provided chains.
-func (h *Hermes) Init(ctx context.Context, chains []types.Chain, opts ...ConfigOption) error {
-	if err := h.generateConfig(ctx, chains, opts...); err != nil {
+func (h *Hermes) Init(ctx context.Context, chains []types.Chain) error {
+	if err := h.generateConfig(ctx, chains, h.configOptions...); err != nil {
 		return fmt.Errorf("failed to generate config: %w", err)
 	}
 
@@ -131,7 +131,7 @@ func (h *Hermes) Init(ctx context.Context, chains []types.Chain, opts ...ConfigO
 
 // GetConfig reads and parses the Hermes configuration file from the container.
 func (h *Hermes) GetConfig(ctx context.Context) (HermesConfig, error) {
-	fileBz, err := h.ReadFile(ctx, ".hermes/config.toml")
+	fileBz, err := h.ReadFile(ctx, hermesConfigPath)
 	if err != nil {
 		return HermesConfig{}, fmt.Errorf("failed to read hermes config: %w", err)
 	}
@@ -144,6 +144,18 @@ func (h *Hermes) GetConfig(ctx context.Context) (HermesConfig, error) {
 	return config, nil
 }
 
+// writeConfig marshals the configuration and writes it to the Hermes configuration file.
+func (h *Hermes) writeConfig(ctx context.Context, config *HermesConfig) error {
+	configTOML, err := config.ToTOML()
+	if err != nil {
+		return fmt.Errorf("failed to marshal hermes config: %w", err)
+	}
+	if err := h.WriteFile(ctx, hermesConfigPath, configTOML); err != nil {
+		return fmt.Errorf("failed to write hermes config: %w", err)
+	}
+	return nil
+}
+
 // setupWallets creates keys on Hermes relayer and funds them from chain faucets.
 func (h *Hermes) setupWallets(ctx context.Context, chains ...types.Chain) error {
 	for _, chain := range chains {
@@ -160,13 +172,13 @@ func (h *Hermes) setupWallets(ctx context.Context, chains ...types.Chain) error
 func (h *Hermes) setupKeyAndWallet(ctx context.Context, chain types.Chain) error {
 	// Create key for chain A on Hermes relayer
 	keyName := fmt.Sprintf("relayer-%s", chain.GetChainID())
-	address, err := h.createRelayerKey(ctx, chain.GetChainID(), keyName)
+	address, err := h.AddKey(ctx, chain, keyName)
 	if err != nil {
 		return fmt.Errorf("failed to create relayer key for chain %s: %w", chain.GetChainID(), err)
 	}
 
 	// fund the relayer addresses from chain faucets
-	err = h.fundRelayerAddress(ctx, chain, address)
+	err = fundRelayerAddress(ctx, chain, address)
 	if err != nil {
 		return fmt.Errorf("failed to fund relayer address on chain %s: %w", chain.GetChainID(), err)
 	}
@@ -186,6 +198,28 @@ func (h *Hermes) CreateClients(ctx context.Context, chainA, chainB types.Chain)
 	return err
 }
 
+// CreateClient creates an IBC client of the reference chain on the host chain and returns its ID, e.g. to
+// substitute an expired or frozen client.
+func (h *Hermes) CreateClient(ctx context.Context, host, reference types.Chain, opts ibc.CreateClientOptions) (string, error) {
+	cmd := []string{"hermes", "--json", "create", "client", "--host-chain", host.GetChainID(), "--reference-chain", reference.GetChainID()}
+	if opts.TrustingPeriod != "" {
+		cmd = append(cmd, "--trusting-period", opts.TrustingPeriod)
+	}
+	stdout, _, err := h.Exec(ctx, h.Logger, cmd, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var clientResponse ClientCreationResponse
+	if err := json.Unmarshal(h.extractJSONResult(stdout), &clientResponse); err != nil {
+		return "", fmt.Errorf("failed to unmarshal client creation response: %w", err)
+	}
+	if clientResponse.Result.CreateClient.ClientID == "" {
+		return "", fmt.Errorf("no client ID found in output")
+	}
+	return clientResponse.Result.CreateClient.ClientID, nil
+}
+
 // CreateConnections creates IBC connections between the chains.
 func (h *Hermes) CreateConnections(ctx context.Context, chainA, chainB types.Chain) (ibc.Connection, error) {
 	cmd := []string{"hermes", "--json", "create", "connection", "--a-chain", chainA.GetChainID(), "--b-chain", chainB.GetChainID()}
@@ -229,6 +263,8 @@ func (h *Hermes) CreateChannel(ctx context.Context, chainA types.Chain, connecti
 	if err != nil {
 		return ibc.Channel{}, fmt.Errorf("failed to parse hermes create channel output: %w", err)
 	}
+	channel.ConnectionID = connection.ConnectionID
+	channel.CounterpartyConnectionID = connection.CounterpartyID
 
 	return channel, nil
 }
@@ -326,22 +362,14 @@ func (h *Hermes) generateConfig(ctx context.Context, chains []types.Chain, opts
 		opt(hermesConfig)
 	}
 
-	configTOML, err := hermesConfig.ToTOML()
-	if err != nil {
-		return fmt.Errorf("failed to marshal hermes config: %w", err)
-	}
-
 	// Write config to the container volume
-	configPath := ".hermes/config.toml"
-	err = h.WriteFile(ctx, configPath, configTOML)
-	if err != nil {
-		return fmt.Errorf("failed to write hermes config: %w", err)
+	if err := h.writeConfig(ctx, hermesConfig); err != nil {
+		return err
 	}
 
 	h.Logger.Info("Hermes config written",
-		zap.Int("config_size", len(configTOML)),
 		zap.Int("chains_count", len(chains)),
-		zap.String("file_path", path.Join(h.HomeDir(), configPath)),
+		zap.String("file_path", path.Join(h.HomeDir(), hermesConfigPath)),
 	)
 	for _, chain := range chains {
 		h.Logger.Info("Chain configured", zap.String("chain_id", chain.GetChainID()))
@@ -372,17 +400,33 @@ func (h *Hermes) validateConfig(ctx context.Context) error {
 	return nil
 }
 
-// createRelayerKey creates a new key on the Hermes relayer for the specified chain
-func (h *Hermes) createRelayerKey(ctx context.Context, chainID, keyName string) (string, error) {
-	// generate a new mnemonic
-	mnemonic, err := h.generateMnemonic()
+// AddKey creates a key for the chain from a new mnemonic and returns its address.
+func (h *Hermes) AddKey(ctx context.Context, chain types.Chain, keyName string) (string, error) {
+	mnemonic, err := generateMnemonic()
 	if err != nil {
-		return "", fmt.Errorf("failed to generate mnemonic: %w", err)
+		return "", err
 	}
+	return h.RestoreKey(ctx, chain, keyName, mnemonic)
+}
+
+// RestoreKey imports the key of the mnemonic for the chain and returns its address. It fails if the chain
+// already has a key with the name.
+func (h *Hermes) RestoreKey(ctx context.Context, chain types.Chain, keyName, mnemonic string) (string, error) {
+	return h.restoreKey(ctx, chain, keyName, mnemonic, false)
+}
+
+// OverwriteKey imports the key of the mnemonic for the chain, replacing any key with the same name, and returns
+// its address.
+func (h *Hermes) OverwriteKey(ctx context.Context, chain types.Chain, keyName, mnemonic string) (string, error) {
+	return h.restoreKey(ctx, chain, keyName, mnemonic, true)
+}
+
+func (h *Hermes) restoreKey(ctx context.Context, chain types.Chain, keyName, mnemonic string, overwrite bool) (string, error) {
+	chainID := chain.GetChainID()
 
 	// write mnemonic to a temporary file in the container
 	mnemonicFile := fmt.Sprintf(".hermes/%s_mnemonic.txt", keyName)
-	err = h.WriteFile(ctx, mnemonicFile, []byte(mnemonic))
+	err := h.WriteFile(ctx, mnemonicFile, []byte(mnemonic))
 	if err != nil {
 		return "", fmt.Errorf("failed to write mnemonic file: %w", err)
 	}
@@ -390,6 +434,9 @@ func (h *Hermes) createRelayerKey(ctx context.Context, chainID, keyName string)
 	// use hermes keys add command and point it at the file we just created.
 	mnemonicPath := path.Join(h.HomeDir(), mnemonicFile)
 	cmd := []string{"hermes", "--json", "keys", "add", "--chain", chainID, "--key-name", keyName, "--mnemonic-file", mnemonicPath}
+	if overwrite {
+		cmd = append(cmd, "--overwrite")
+	}
 	stdout, _, err := h.Exec(ctx, h.Logger, cmd, nil)
 	if err != nil {
 		h.Logger.Error("Failed to create relayer key",
@@ -415,19 +462,29 @@ func (h *Hermes) createRelayerKey(ctx context.Context, chainID, keyName string)
 	return address, nil
 }
 
-// generateMnemonic generates a new BIP39 mnemonic
-func (h *Hermes) generateMnemonic() (string, error) {
-	entropy, err := bip39.NewEntropy(256) // 24 words
+// UseKey sets the key Hermes signs its transactions on the chain with in the configuration.
+func (h *Hermes) UseKey(ctx context.Context, chain types.Chain, keyName string) error {
+	config, err := h.GetConfig(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate entropy: %w", err)
+		return err
 	}
 
-	mnemonic, err := bip39.NewMnemonic(entropy)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate mnemonic: %w", err)
+	for i := range config.Chains {
+		if config.Chains[i].ID == chain.GetChainID() {
+			config.Chains[i].KeyName = keyName
+			return h.writeConfig(ctx, &config)
+		}
 	}
+	return fmt.Errorf("chain %s not found in hermes config", chain.GetChainID())
+}
 
-	return mnemonic, nil
+// Flush relays the packets and acknowledgements pending on the channel of chainA, in both directions.
+func (h *Hermes) Flush(ctx context.Context, chainA types.Chain, channel ibc.Channel) error {
+	cmd := []string{"hermes", "--json", "clear", "packets", "--chain", chainA.GetChainID(), "--port", channel.PortID, "--channel", channel.ChannelID}
+	if _, _, err := h.Exec(ctx, h.Logger, cmd, nil); err != nil {
+		return fmt.Errorf("failed to clear packets on channel %s: %w", channel.ChannelID, err)
+	}
+	return nil
 }
 
 // parseAddressFromKeyOutput extracts the address from hermes key creation output
@@ -438,63 +495,11 @@ func (h *Hermes) parseAddressFromKeyOutput(output string) (string, error) {
 		// JSON format - extract address from the result
 		if result, ok := jsonResult["result"].(string); ok {
 			// Use regex to find bech32 address in the result string
-			return h.extractAddressWithRegex(result)
+			return extractAddress(result)
 		}
 	}
 
 	// Fallback to text parsing for non-JSON output
 	// Use regex to find any bech32 address pattern (works for any chain prefix)
-	return h.extractAddressWithRegex(output)
-}
-
-// extractAddressWithRegex uses regex to find bech32 addresses
-func (h *Hermes) extractAddressWithRegex(text string) (string, error) {
-	// Bech32 address regex pattern: prefix + 1 + base32 characters (at least 38 chars total)
-	// This will match addresses like: cosmos1..., celestia1..., osmo1..., etc.
-	bech32Pattern := `([a-z]+1[a-z0-9]{38,})`
-	re := regexp.MustCompile(bech32Pattern)
-
-	matches := re.FindStringSubmatch(text)
-	if len(matches) > 1 {
-		address := matches[1]
-		// remove any trailing punctuation that might have been captured
-		address = strings.TrimRight(address, ".,;:!?)")
-		return address, nil
-	}
-
-	return "", fmt.Errorf("could not find bech32 address in output: %s", text)
-}
-
-// fundRelayerAddress funds a relayer address from a faucet wallet.
-func (h *Hermes) fundRelayerAddress(ctx context.Context, chain types.Chain, relayerAddress string) error {
-	// Get the chain's faucet wallet and config
-	faucet := chain.GetFaucetWallet()
-	chainRelayerConfig := chain.GetRelayerConfig()
-
-	// Get faucet address
-	fromAddr, err := sdkacc.AddressFromWallet(faucet)
-	if err != nil {
-		return fmt.Errorf("failed to get faucet address: %w", err)
-	}
-
-	// Parse the relayer address
-	toAddr, err := sdk.AccAddressFromBech32(relayerAddress)
-	if err != nil {
-		return fmt.Errorf("failed to parse relayer address %s: %w", relayerAddress, err)
-	}
-
-	// Define amount to fund the relayer wallet (enough for relayer operations)
-	// Use the chain's native denom from the config
-	fundAmount := sdk.NewCoins(sdk.NewCoin(chainRelayerConfig.Denom, sdkmath.NewInt(10000000))) // 10 tokens
-	bankSend := banktypes.NewMsgSend(fromAddr, toAddr, fundAmount)
-	resp, err := chain.BroadcastMessages(ctx, faucet, bankSend)
-	if err != nil {
-		return fmt.Errorf("failed to broadcast funding transaction: %w", err)
-	}
-
-	if resp.Code != 0 {
-		return fmt.Errorf("funding transaction failed: %s", resp.RawLog)
-	}
-
-	return nil
+	return extractAddress(output)
 }
@@ -166,6 +166,9 @@ func NewHermesConfig(chains []types.ChainRelayerConfig) (*HermesConfig, error) {
 			},
 			MemoPrefix: "",
 		}
+		if chainCfg.TrustingPeriod != "" {
+			hermesChains[i].TrustingPeriod = chainCfg.TrustingPeriod
+		}
 	}
 
 	return &HermesConfig{
@@ -34,3 +34,18 @@ type CreateConnectionResult struct {
 type ConnectionSide struct {
 	ConnectionID string `json:"connection_id"`
 }
+
+// ClientCreationResponse represents the response from hermes create client command
+type ClientCreationResponse struct {
+	Result CreateClientResult `json:"result"`
+}
+
+// CreateClientResult holds the event emitted by the creation of the client
+type CreateClientResult struct {
+	CreateClient ClientSide `json:"CreateClient"`
+}
+
+// ClientSide captures the ID of the created client
+type ClientSide struct {
+	ClientID string `json:"client_id"`
+}
@@ -15,3 +15,17 @@ var HermesRelayer = HermesNodeType{}
 
 // Interface Compliance Check - ensure HermesNodeType implements the NodeType interface
 var _ types.NodeType = (*HermesNodeType)(nil)
+
+// GoRelayerNodeType represents a cosmos Go relayer (rly) IBC relayer node
+type GoRelayerNodeType struct{}
+
+// String returns the string representation of the GoRelayerNodeType
+func (r GoRelayerNodeType) String() string {
+	return "rly"
+}
+
+// GoRelayerRelayer is the singleton instance representing a Go relayer IBC relayer node
+var GoRelayerRelayer = GoRelayerNodeType{}
+
+// Interface Compliance Check - ensure GoRelayerNodeType implements the NodeType interface
+var _ types.NodeType = (*GoRelayerNodeType)(nil)
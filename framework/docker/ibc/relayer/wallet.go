@@ -0,0 +1,82 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/go-bip39"
+)
+
+// generateMnemonic generates a new BIP39 mnemonic
+func generateMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256) // 24 words
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate mnemonic: %w", err)
+	}
+
+	return mnemonic, nil
+}
+
+// fundRelayerAddress funds a relayer address from a faucet wallet.
+func fundRelayerAddress(ctx context.Context, chain types.Chain, relayerAddress string) error {
+	// Get the chain's faucet wallet and config
+	faucet := chain.GetFaucetWallet()
+	chainRelayerConfig := chain.GetRelayerConfig()
+
+	// Get faucet address
+	fromAddr, err := sdkacc.AddressFromWallet(faucet)
+	if err != nil {
+		return fmt.Errorf("failed to get faucet address: %w", err)
+	}
+
+	// Parse the relayer address
+	toAddr, err := sdk.AccAddressFromBech32(relayerAddress)
+	if err != nil {
+		return fmt.Errorf("failed to parse relayer address %s: %w", relayerAddress, err)
+	}
+
+	// Define amount to fund the relayer wallet (enough for relayer operations)
+	// Use the chain's native denom from the config
+	fundAmount := sdk.NewCoins(sdk.NewCoin(chainRelayerConfig.Denom, sdkmath.NewInt(10000000))) // 10 tokens
+	bankSend := banktypes.NewMsgSend(fromAddr, toAddr, fundAmount)
+	resp, err := chain.BroadcastMessages(ctx, faucet, bankSend)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast funding transaction: %w", err)
+	}
+
+	if resp.Code != 0 {
+		return fmt.Errorf("funding transaction failed: %s", resp.RawLog)
+	}
+
+	return nil
+}
+
+// extractAddress uses regex to find the bech32 address in the output of a relayer command.
+func extractAddress(text string) (string, error) {
+	// Bech32 address regex pattern: prefix + 1 + base32 characters (at least 38 chars total)
+	// This will match addresses like: cosmos1..., celestia1..., osmo1..., etc.
+	bech32Pattern := `([a-z]+1[a-z0-9]{38,})`
+	re := regexp.MustCompile(bech32Pattern)
+
+	matches := re.FindStringSubmatch(text)
+	if len(matches) > 1 {
+		address := matches[1]
+		// remove any trailing punctuation that might have been captured
+		address = strings.TrimRight(address, ".,;:!?)")
+		return address, nil
+	}
+
+	return "", fmt.Errorf("could not find bech32 address in output: %s", text)
+}
@@ -0,0 +1,145 @@
+package ibc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/testutil/events"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/grpc"
+	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// DefaultTransferTimeout is the time after which a transfer times out if neither of its timeouts is set.
+const DefaultTransferTimeout = time.Hour
+
+// TransferOptions configures an ICS-20 transfer.
+type TransferOptions struct {
+	// TimeoutHeight is the height of the destination chain from which the packet times out.
+	TimeoutHeight clienttypes.Height
+	// TimeoutTimestamp is the block time of the destination chain from which the packet times out.
+	// If neither timeout is set, the packet times out after DefaultTransferTimeout.
+	TimeoutTimestamp time.Time
+	// Memo is the memo of the transfer packet.
+	Memo string
+}
+
+// Transfer is an ICS-20 transfer sent on a channel.
+type Transfer struct {
+	// TxResponse is the response of the transaction sending the transfer.
+	TxResponse sdk.TxResponse
+	// Channel is the channel the transfer was sent on, as seen from the sending chain.
+	Channel Channel
+	// Sequence is the sequence of the transfer packet.
+	Sequence uint64
+	// Sender is the address of the sender on the sending chain.
+	Sender string
+	// Receiver is the address of the receiver on the receiving chain.
+	Receiver string
+	// Amount is the amount sent, in the denom of the sending chain.
+	Amount sdk.Coin
+	// ReceivedDenom is the denom of the tokens on the receiving chain.
+	ReceivedDenom string
+	// TimeoutHeight is the height of the receiving chain from which the packet times out.
+	TimeoutHeight clienttypes.Height
+	// TimeoutTimestamp is the block time of the receiving chain from which the packet times out.
+	TimeoutTimestamp time.Time
+}
+
+// SendTransfer sends an ICS-20 transfer of the amount from the sender to the receiver on the channel of the chain.
+// The denom of the amount is the denom trace of the tokens on the chain, e.g. "utia" for native tokens or
+// "transfer/channel-0/stake" for tokens previously received over IBC.
+func SendTransfer(ctx context.Context, chain types.Chain, sender *types.Wallet, channel Channel, amount sdk.Coin, receiver string, opts TransferOptions) (Transfer, error) {
+	timeoutTimestamp := opts.TimeoutTimestamp
+	if opts.TimeoutHeight.IsZero() && timeoutTimestamp.IsZero() {
+		timeoutTimestamp = time.Now().Add(DefaultTransferTimeout)
+	}
+	var timeoutNanos uint64
+	if !timeoutTimestamp.IsZero() {
+		timeoutNanos = uint64(timeoutTimestamp.UnixNano())
+	}
+
+	token := sdk.NewCoin(ibctransfertypes.ParseDenomTrace(amount.Denom).IBCDenom(), amount.Amount)
+	msg := ibctransfertypes.NewMsgTransfer(channel.PortID, channel.ChannelID, token, sender.GetFormattedAddress(), receiver, opts.TimeoutHeight, timeoutNanos, opts.Memo)
+
+	resp, err := chain.BroadcastMessages(ctx, sender, msg)
+	if err != nil {
+		return Transfer{}, fmt.Errorf("failed to broadcast transfer: %w", err)
+	}
+	if err := events.TxError(resp); err != nil {
+		return Transfer{}, fmt.Errorf("transfer failed: %w", err)
+	}
+
+	value, err := events.AttributeValue(resp.Events, channeltypes.EventTypeSendPacket, channeltypes.AttributeKeySequence)
+	if err != nil {
+		return Transfer{}, err
+	}
+	sequence, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return Transfer{}, fmt.Errorf("failed to parse packet sequence %q: %w", value, err)
+	}
+
+	return Transfer{
+		TxResponse:       resp,
+		Channel:          channel,
+		Sequence:         sequence,
+		Sender:           sender.GetFormattedAddress(),
+		Receiver:         receiver,
+		Amount:           amount,
+		ReceivedDenom:    ReceivedDenomTrace(channel, amount.Denom).IBCDenom(),
+		TimeoutHeight:    opts.TimeoutHeight,
+		TimeoutTimestamp: timeoutTimestamp,
+	}, nil
+}
+
+// ReceivedDenomTrace returns the denom trace on the receiving chain of tokens sent on the channel, given their denom
+// trace on the sending chain. Tokens returning to the chain they came from are unwound, other tokens are prefixed
+// with the port and channel of the receiving chain.
+func ReceivedDenomTrace(channel Channel, denom string) ibctransfertypes.DenomTrace {
+	if ibctransfertypes.ReceiverChainIsSource(channel.PortID, channel.ChannelID, denom) {
+		return ibctransfertypes.ParseDenomTrace(strings.TrimPrefix(denom, ibctransfertypes.GetDenomPrefix(channel.PortID, channel.ChannelID)))
+	}
+	return ibctransfertypes.ParseDenomTrace(ibctransfertypes.GetPrefixedDenom(channel.CounterpartyPort, channel.CounterpartyID, denom))
+}
+
+// EscrowBalance returns the balance of the escrow account of the channel. Native tokens sent on the channel are
+// escrowed until they are received, and refunded from escrow if the transfer times out or fails.
+func EscrowBalance(ctx context.Context, conn grpc.ClientConn, channel Channel, denom string) (sdkmath.Int, error) {
+	escrow := ibctransfertypes.GetEscrowAddress(channel.PortID, channel.ChannelID)
+	return query.Balance(ctx, conn, escrow.String(), denom)
+}
+
+// WaitForTimeout waits until the receiving chain is past the timeout of the transfer, after which relaying the
+// packet times it out and refunds the sender. To force a timeout, stop the relayer, or pause the receiving chain
+// with cosmos.Chain.Pause, until then. A paused chain does not respond, so it has to be resumed before waiting.
+func WaitForTimeout(ctx context.Context, dst types.Chain, transfer Transfer, timeout time.Duration) error {
+	nodes := dst.GetNodes()
+	if len(nodes) == 0 {
+		return fmt.Errorf("chain %s has no nodes", dst.GetChainID())
+	}
+	rpcClient, err := nodes[0].GetRPCClient()
+	if err != nil {
+		return fmt.Errorf("failed to get rpc client: %w", err)
+	}
+
+	return wait.ForCondition(ctx, timeout, time.Second, func() (bool, error) {
+		status, err := rpcClient.Status(ctx)
+		if err != nil {
+			return false, err
+		}
+		height := uint64(status.SyncInfo.LatestBlockHeight)
+		if !transfer.TimeoutHeight.IsZero() && height >= transfer.TimeoutHeight.RevisionHeight {
+			return true, nil
+		}
+		return !transfer.TimeoutTimestamp.IsZero() && !status.SyncInfo.LatestBlockTime.Before(transfer.TimeoutTimestamp), nil
+	})
+}
@@ -25,6 +25,10 @@ type Channel struct {
 	State            string
 	Order            ChannelOrder
 	Version          string
+	// ConnectionID is the connection the channel is opened on.
+	ConnectionID string
+	// CounterpartyConnectionID is the connection the counterparty channel is opened on.
+	CounterpartyConnectionID string
 }
 
 // Connection represents an IBC connection between two chains.
@@ -35,3 +39,9 @@ type Connection struct {
 	CounterpartyClientID string
 	State                string
 }
+
+// CreateClientOptions defines options for creating an IBC client.
+type CreateClientOptions struct {
+	// TrustingPeriod overrides the trusting period of the client, e.g. "2m".
+	TrustingPeriod string
+}
@@ -0,0 +1,94 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	"github.com/celestiaorg/tastora/framework/docker/ibc"
+	"github.com/celestiaorg/tastora/framework/testutil/config"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	cometcfg "github.com/cometbft/cometbft/config"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIBCClientRecovery verifies that clients of simapp on celestia-app expire when they are not updated within
+// their trusting period, are frozen by double sign misbehaviour, and can be recovered through governance.
+func TestIBCClientRecovery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	// the relayer is never started, so the clients are not updated.
+	ibcCfg := setupIBCDockerTest(t)
+	ctx := ibcCfg.Ctx
+
+	host := ibcCfg.chainA.(*cosmos.Chain)
+	reference := ibcCfg.chainB.(*cosmos.Chain)
+	hostConn := host.GetNode().GrpcConn
+
+	// recoverClient creates a substitute client and recovers the subject client with it.
+	recoverClient := func(t *testing.T, subjectClientID string) {
+		substituteClientID, err := ibcCfg.relayer.CreateClient(ctx, host, reference, ibc.CreateClientOptions{})
+		require.NoError(t, err)
+		require.NoError(t, ibc.WaitForClientStatus(ctx, hostConn, substituteClientID, ibcexported.Active, time.Minute))
+
+		require.NoError(t, host.RecoverClient(ctx, subjectClientID, substituteClientID))
+		require.NoError(t, ibc.WaitForClientStatus(ctx, hostConn, subjectClientID, ibcexported.Active, time.Minute))
+	}
+
+	t.Run("expired client", func(t *testing.T) {
+		clientID, err := ibcCfg.relayer.CreateClient(ctx, host, reference, ibc.CreateClientOptions{TrustingPeriod: "30s"})
+		require.NoError(t, err)
+		require.NoError(t, ibc.WaitForClientStatus(ctx, hostConn, clientID, ibcexported.Active, time.Minute))
+
+		require.NoError(t, ibc.WaitForClientStatus(ctx, hostConn, clientID, ibcexported.Expired, 2*time.Minute))
+
+		recoverClient(t, clientID)
+	})
+
+	t.Run("frozen client", func(t *testing.T) {
+		privValidatorKey, err := reference.Validators[0].ReadPrivValidatorKey(ctx)
+		require.NoError(t, err)
+
+		// the fork has the chain ID of the reference chain and its validator signs with the key of the validator of
+		// the reference chain, so both commit conflicting blocks at the same heights.
+		fork, err := newSimappChainBuilder(t, ibcCfg.DockerClient, ibcCfg.NetworkID, ibcCfg.EncConfig, ibcCfg.TestName+"-fork").
+			WithPostInit(func(ctx context.Context, node *cosmos.ChainNode) error {
+				// the fork starts from genesis, faster blocks let it catch up with the reference chain.
+				return config.Modify(ctx, node, "config/config.toml", func(cfg *cometcfg.Config) {
+					cfg.Consensus.TimeoutCommit = 100 * time.Millisecond
+				})
+			}).
+			WithNode(cosmos.NewChainNodeConfigBuilder().WithPrivValidatorKey(privValidatorKey).Build()).
+			Build(ctx)
+		require.NoError(t, err)
+		require.NoError(t, fork.Start(ctx))
+
+		clientID, err := ibcCfg.relayer.CreateClient(ctx, host, reference, ibc.CreateClientOptions{})
+		require.NoError(t, err)
+
+		// the misbehaviour is at a height past the latest height of the client, which the fork has to reach.
+		clientHeight, err := reference.Height(ctx)
+		require.NoError(t, err)
+		require.NoError(t, wait.ForCondition(ctx, 5*time.Minute, time.Second, func() (bool, error) {
+			forkHeight, err := fork.Height(ctx)
+			if err != nil {
+				return false, err
+			}
+			return forkHeight > clientHeight, nil
+		}))
+		require.NoError(t, wait.ForBlocks(ctx, 2, reference))
+
+		misbehaviour, err := ibc.NewDoubleSignMisbehaviour(ctx, hostConn, clientID, reference.GetNode(), fork.GetNode())
+		require.NoError(t, err)
+		_, err = ibc.SubmitMisbehaviour(ctx, host, host.GetFaucetWallet(), clientID, misbehaviour)
+		require.NoError(t, err)
+		require.NoError(t, ibc.WaitForClientStatus(ctx, hostConn, clientID, ibcexported.Frozen, time.Minute))
+
+		recoverClient(t, clientID)
+	})
+}
@@ -0,0 +1,110 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	"github.com/celestiaorg/tastora/framework/docker/ibc"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIBCPacketFlush verifies that packets are only relayed when the channel is flushed, and that timed out
+// packets are refunded once their timeout is relayed.
+func TestIBCPacketFlush(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	// the relayer is never started, packets are only relayed by flushing the channel.
+	ibcCfg := setupIBCDockerTest(t)
+	ctx := ibcCfg.Ctx
+
+	connA := ibcCfg.chainA.(*cosmos.Chain).GetNode().GrpcConn
+	connB := ibcCfg.chainB.(*cosmos.Chain).GetNode().GrpcConn
+	denom := ibcCfg.chainA.GetRelayerConfig().Denom
+	ibcDenom := ibc.ReceivedDenomTrace(ibcCfg.channel, denom).IBCDenom()
+
+	senderWallet := ibcCfg.chainA.GetFaucetWallet()
+	receiverAddr, err := sdkacc.AddressFromWallet(ibcCfg.chainB.GetFaucetWallet())
+	require.NoError(t, err)
+
+	transferAmount := sdkmath.NewInt(100_000)
+	transfer := func(t *testing.T, timeout time.Duration) ibc.Transfer {
+		transfer, err := ibc.SendTransfer(ctx, ibcCfg.chainA, senderWallet, ibcCfg.channel, sdk.NewCoin(denom, transferAmount),
+			receiverAddr.String(), ibc.TransferOptions{TimeoutTimestamp: time.Now().Add(timeout)})
+		require.NoError(t, err)
+		return transfer
+	}
+
+	t.Run("flush relays pending packets", func(t *testing.T) {
+		initialReceiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, ibcDenom)
+
+		transfer(t, time.Hour)
+
+		pending, err := ibc.QueryPendingPackets(ctx, connA, connB, ibcCfg.channel)
+		require.NoError(t, err)
+		require.Len(t, pending.Unreceived, 1, "the transfer should be pending until the channel is flushed")
+
+		require.NoError(t, ibcCfg.relayer.Flush(ctx, ibcCfg.chainA, ibcCfg.channel))
+		require.NoError(t, ibc.WaitForFlushed(ctx, connA, connB, ibcCfg.channel, time.Minute))
+
+		receiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, ibcDenom)
+		require.True(t, receiverBalance.Equal(initialReceiverBalance.Add(transferAmount)),
+			"receiver balance mismatch: expected %s, got %s", initialReceiverBalance.Add(transferAmount), receiverBalance)
+	})
+
+	t.Run("flush relays timeouts", func(t *testing.T) {
+		initialReceiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, ibcDenom)
+		initialEscrowBalance, err := ibc.EscrowBalance(ctx, connA, ibcCfg.channel, denom)
+		require.NoError(t, err)
+
+		timedOut := transfer(t, 15*time.Second)
+
+		escrowBalance, err := ibc.EscrowBalance(ctx, connA, ibcCfg.channel, denom)
+		require.NoError(t, err)
+		require.True(t, escrowBalance.Equal(initialEscrowBalance.Add(transferAmount)), "the transfer should be escrowed")
+
+		// let the timeout pass before the packet is relayed.
+		require.NoError(t, ibc.WaitForTimeout(ctx, ibcCfg.chainB, timedOut, time.Minute))
+
+		pending, err := ibc.QueryPendingPackets(ctx, connA, connB, ibcCfg.channel)
+		require.NoError(t, err)
+		require.Len(t, pending.Unreceived, 1, "the timed out transfer should be pending until the channel is flushed")
+
+		require.NoError(t, ibcCfg.relayer.Flush(ctx, ibcCfg.chainA, ibcCfg.channel))
+		require.NoError(t, ibc.WaitForFlushed(ctx, connA, connB, ibcCfg.channel, time.Minute))
+
+		receiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, ibcDenom)
+		require.True(t, receiverBalance.Equal(initialReceiverBalance), "the timed out transfer should not be received")
+
+		escrowBalance, err = ibc.EscrowBalance(ctx, connA, ibcCfg.channel, denom)
+		require.NoError(t, err)
+		require.True(t, escrowBalance.Equal(initialEscrowBalance), "the timed out transfer should be refunded")
+	})
+
+	t.Run("flush from the counterparty chain", func(t *testing.T) {
+		counterparty := ibcCfg.channel.Counterparty()
+		denomB := ibcCfg.chainB.GetRelayerConfig().Denom
+		ibcDenomB := ibc.ReceivedDenomTrace(counterparty, denomB).IBCDenom()
+
+		receiverAddrA, err := sdkacc.AddressFromWallet(senderWallet)
+		require.NoError(t, err)
+		initialReceiverBalance := getBalance(t, ctx, ibcCfg.chainA, receiverAddrA, ibcDenomB)
+
+		_, err = ibc.SendTransfer(ctx, ibcCfg.chainB, ibcCfg.chainB.GetFaucetWallet(), counterparty, sdk.NewCoin(denomB, transferAmount),
+			receiverAddrA.String(), ibc.TransferOptions{TimeoutTimestamp: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+
+		require.NoError(t, ibcCfg.relayer.Flush(ctx, ibcCfg.chainB, counterparty))
+		require.NoError(t, ibc.WaitForFlushed(ctx, connB, connA, counterparty, time.Minute))
+
+		receiverBalance := getBalance(t, ctx, ibcCfg.chainA, receiverAddrA, ibcDenomB)
+		require.True(t, receiverBalance.Equal(initialReceiverBalance.Add(transferAmount)),
+			"receiver balance mismatch: expected %s, got %s", initialReceiverBalance.Add(transferAmount), receiverBalance)
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/celestiaorg/tastora/framework/docker/container"
 	"github.com/celestiaorg/tastora/framework/docker/cosmos"
 	"github.com/celestiaorg/tastora/framework/docker/ibc"
-	"github.com/celestiaorg/tastora/framework/docker/ibc/relayer"
 	"github.com/celestiaorg/tastora/framework/testutil/config"
 	"github.com/celestiaorg/tastora/framework/types"
 	servercfg "github.com/cosmos/cosmos-sdk/server/config"
@@ -40,15 +39,19 @@ func createCelestiaChain(t *testing.T, ctx context.Context, client types.Tastora
 				cfg.API.Enable = true
 				cfg.MinGasPrices = "0.0utia"
 			})
-		}).
-		WithNode(cosmos.NewChainNodeConfigBuilder().Build())
-
-	return builder.Build(ctx)
+		})
 }
 
 // createSimappChain creates an IBC-Go simapp chain for IBC testing
 func createSimappChain(t *testing.T, ctx context.Context, client types.TastoraDockerClient, networkID string, encConfig testutil.TestEncodingConfig, testName string) (types.Chain, error) {
-	builder := cosmos.NewChainBuilderWithTestName(t, testName).
+	return newSimappChainBuilder(t, client, networkID, encConfig, testName).
+		WithNode(cosmos.NewChainNodeConfigBuilder().Build()).
+		Build(ctx)
+}
+
+// newSimappChainBuilder returns the builder of the simapp chain without any nodes.
+func newSimappChainBuilder(t *testing.T, client types.TastoraDockerClient, networkID string, encConfig testutil.TestEncodingConfig, testName string) *cosmos.ChainBuilder {
+	return cosmos.NewChainBuilderWithTestName(t, testName).
 		WithDockerClient(client).
 		WithDockerNetworkID(networkID).
 		WithChainID("chain-b").
@@ -70,20 +73,17 @@ func createSimappChain(t *testing.T, ctx context.Context, client types.TastoraDo
 				cfg.API.Enable = true
 				cfg.API.EnableUnsafeCORS = true
 			})
-		}).
-		WithNode(cosmos.NewChainNodeConfigBuilder().Build())
-
-	return builder.Build(ctx)
+		})
 }
 
 // setupIBCConnection establishes a complete IBC connection and channel
-func setupIBCConnection(t *testing.T, ctx context.Context, chainA, chainB types.Chain, hermes *relayer.Hermes) (ibc.Connection, ibc.Channel) {
+func setupIBCConnection(t *testing.T, ctx context.Context, chainA, chainB types.Chain, rly ibc.Relayer) (ibc.Connection, ibc.Channel) {
 	// create clients
-	err := hermes.CreateClients(ctx, chainA, chainB)
+	err := rly.CreateClients(ctx, chainA, chainB)
 	require.NoError(t, err)
 
 	// create connections
-	connection, err := hermes.CreateConnections(ctx, chainA, chainB)
+	connection, err := rly.CreateConnections(ctx, chainA, chainB)
 	require.NoError(t, err)
 	require.NotEmpty(t, connection.ConnectionID, "Connection ID should not be empty")
 
@@ -95,7 +95,7 @@ func setupIBCConnection(t *testing.T, ctx context.Context, chainA, chainB types.
 		Version:        "ics20-1",
 	}
 
-	channel, err := hermes.CreateChannel(ctx, chainA, connection, channelOpts)
+	channel, err := rly.CreateChannel(ctx, chainA, connection, channelOpts)
 	require.NoError(t, err)
 	require.NotNil(t, channel)
 	require.NotEmpty(t, channel.ChannelID, "Channel ID should not be empty")
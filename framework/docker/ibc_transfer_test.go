@@ -16,10 +16,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	govmodule "github.com/cosmos/cosmos-sdk/x/gov"
+	ica "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts"
 	"github.com/cosmos/ibc-go/v8/modules/apps/transfer"
-	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	ibccore "github.com/cosmos/ibc-go/v8/modules/core"
 	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"golang.org/x/sync/errgroup"
 	"testing"
@@ -33,17 +37,31 @@ type IBCTestSetupConfig struct {
 	// IBC-specific components
 	chainA     types.Chain // celestia-app chain
 	chainB     types.Chain // simapp chain
-	relayer    *relayer.Hermes
+	relayer    ibc.Relayer
 	connection ibc.Connection
 	channel    ibc.Channel
 }
 
 func setupIBCDockerTest(t *testing.T) *IBCTestSetupConfig {
+	return setupIBCDockerTestWithRelayer(t, func(ctx context.Context, dockerClient types.TastoraDockerClient, testName, networkID string, logger *zap.Logger) (ibc.Relayer, error) {
+		return relayer.NewHermes(ctx, dockerClient, testName, networkID, 0, logger, func(config *relayer.HermesConfig) {
+			// apply a modification that is not the default.
+			config.Chains[0].ClockDrift = "6s"
+			config.Chains[1].ClockDrift = "6s"
+		})
+	})
+}
+
+// newRelayerFunc creates the relayer of an IBC test.
+type newRelayerFunc func(ctx context.Context, dockerClient types.TastoraDockerClient, testName, networkID string, logger *zap.Logger) (ibc.Relayer, error)
+
+func setupIBCDockerTestWithRelayer(t *testing.T, newRelayer newRelayerFunc) *IBCTestSetupConfig {
 	configureBech32PrefixOnce()
 
 	ctx := context.Background()
 	logger := zaptest.NewLogger(t)
-	encConfig := testutil.MakeTestEncodingConfig(auth.AppModuleBasic{}, bank.AppModuleBasic{}, transfer.AppModuleBasic{})
+	encConfig := testutil.MakeTestEncodingConfig(auth.AppModuleBasic{}, bank.AppModuleBasic{}, transfer.AppModuleBasic{}, ica.AppModuleBasic{},
+		ibccore.AppModuleBasic{}, ibctm.AppModuleBasic{}, govmodule.AppModuleBasic{})
 
 	// Generate unique test name for parallel execution
 	uniqueTestName := fmt.Sprintf("%s-%s", t.Name(), random.LowerCaseLetterString(8))
@@ -69,25 +87,22 @@ func setupIBCDockerTest(t *testing.T) *IBCTestSetupConfig {
 	require.NoError(t, eg.Wait(), "failed to start chains")
 
 	// Create and initialize relayer (but don't start it)
-	hermes, err := relayer.NewHermes(ctx, dockerClient, uniqueTestName, networkID, 0, logger)
-	require.NoError(t, err, "failed to create hermes relayer")
-
-	err = hermes.Init(ctx, []types.Chain{chainA, chainB}, func(config *relayer.HermesConfig) {
-		// apply a modification that is not the default.
-		config.Chains[0].ClockDrift = "6s"
-		config.Chains[1].ClockDrift = "6s"
-	})
+	rly, err := newRelayer(ctx, dockerClient, uniqueTestName, networkID, logger)
+	require.NoError(t, err, "failed to create relayer")
 
+	err = rly.Init(ctx, []types.Chain{chainA, chainB})
 	require.NoError(t, err, "failed to initialize relayer")
 
-	cfg, err := hermes.GetConfig(ctx)
-	require.NoError(t, err, "failed to get relayer config")
+	if hermes, ok := rly.(*relayer.Hermes); ok {
+		cfg, err := hermes.GetConfig(ctx)
+		require.NoError(t, err, "failed to get relayer config")
 
-	require.Equal(t, cfg.Chains[0].ClockDrift, "6s", "clock drift should be 6s")
-	require.Equal(t, cfg.Chains[1].ClockDrift, "6s", "clock drift should be 6s")
+		require.Equal(t, cfg.Chains[0].ClockDrift, "6s", "clock drift should be 6s")
+		require.Equal(t, cfg.Chains[1].ClockDrift, "6s", "clock drift should be 6s")
+	}
 
 	// Setup IBC connection and channel
-	connection, channel := setupIBCConnection(t, ctx, chainA, chainB, hermes)
+	connection, channel := setupIBCConnection(t, ctx, chainA, chainB, rly)
 
 	ibcCfg := &IBCTestSetupConfig{
 		TestSetupConfig: TestSetupConfig{
@@ -100,7 +115,7 @@ func setupIBCDockerTest(t *testing.T) *IBCTestSetupConfig {
 		},
 		chainA:     chainA,
 		chainB:     chainB,
-		relayer:    hermes,
+		relayer:    rly,
 		connection: connection,
 		channel:    channel,
 	}
@@ -116,7 +131,24 @@ func TestIBCTransfer(t *testing.T) {
 	t.Parallel()
 
 	// Setup IBC environment
-	ibcCfg := setupIBCDockerTest(t)
+	testIBCTransfer(t, setupIBCDockerTest(t))
+}
+
+// TestIBCTransferGoRelayer tests a complete IBC token transfer between celestia-app and simapp relayed by the
+// Go relayer instead of Hermes.
+func TestIBCTransferGoRelayer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testIBCTransfer(t, setupIBCDockerTestWithRelayer(t, func(ctx context.Context, dockerClient types.TastoraDockerClient, testName, networkID string, logger *zap.Logger) (ibc.Relayer, error) {
+		return relayer.NewGoRelayer(ctx, dockerClient, testName, networkID, 0, logger)
+	}))
+}
+
+// testIBCTransfer sends tokens from chain A to chain B and verifies that they are relayed.
+func testIBCTransfer(t *testing.T, ibcCfg *IBCTestSetupConfig) {
 	ctx := ibcCfg.Ctx
 
 	// send from faucet wallet on chain A
@@ -135,7 +167,7 @@ func TestIBCTransfer(t *testing.T) {
 	require.NoError(t, err)
 
 	// Calculate the IBC denom for chainA's token on chainB
-	ibcDenom := calculateIBCDenom(ibcCfg.channel.CounterpartyPort, ibcCfg.channel.CounterpartyID, ibcCfg.chainA.GetRelayerConfig().Denom)
+	ibcDenom := ibc.ReceivedDenomTrace(ibcCfg.channel, ibcCfg.chainA.GetRelayerConfig().Denom).IBCDenom()
 	initialReceiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, ibcDenom)
 	t.Logf("Receiver initial IBC balance: %s %s", initialReceiverBalance.String(), ibcDenom)
 
@@ -143,24 +175,14 @@ func TestIBCTransfer(t *testing.T) {
 	transferAmount := sdkmath.NewInt(100000) // 0.1 tokens
 	t.Logf("Sending IBC transfer: %s %s from %s to %s", transferAmount.String(), ibcCfg.chainA.GetRelayerConfig().Denom, ibcCfg.chainA.GetChainID(), ibcCfg.chainB.GetChainID())
 
-	t.Logf("Starting Hermes relayer...")
+	t.Logf("Starting relayer...")
 	err = ibcCfg.relayer.Start(ctx)
 	require.NoError(t, err)
 
-	ibcTransfer := ibctransfertypes.NewMsgTransfer(
-		ibcCfg.channel.PortID,
-		ibcCfg.channel.ChannelID,
-		sdk.NewCoin(ibcCfg.chainA.GetRelayerConfig().Denom, transferAmount),
-		senderWallet.GetFormattedAddress(),
-		receiverAddr.String(),
-		clienttypes.ZeroHeight(),
-		uint64(time.Now().Add(time.Hour).UnixNano()),
-		"",
-	)
-
-	resp, err := ibcCfg.chainA.BroadcastMessages(ctx, senderWallet, ibcTransfer)
+	transfer, err := ibc.SendTransfer(ctx, ibcCfg.chainA, senderWallet, ibcCfg.channel,
+		sdk.NewCoin(ibcCfg.chainA.GetRelayerConfig().Denom, transferAmount), receiverAddr.String(), ibc.TransferOptions{})
 	require.NoError(t, err)
-	require.Equal(t, uint32(0), resp.Code, "IBC transfer failed: %s", resp.RawLog)
+	require.Equal(t, ibcDenom, transfer.ReceivedDenom)
 
 	// Wait a moment for the escrow transaction to be reflected in balances
 	t.Logf("Waiting for balance updates...")
@@ -192,6 +214,118 @@ func TestIBCTransfer(t *testing.T) {
 	t.Logf("IBC transfer completed successfully!")
 }
 
+// TestIBCTransferRefunds verifies that transfers which time out or are acknowledged with an error are refunded.
+func TestIBCTransferRefunds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	ibcCfg := setupIBCDockerTest(t)
+	ctx := ibcCfg.Ctx
+
+	connA := ibcCfg.chainA.(*cosmos.Chain).GetNode().GrpcConn
+	connB := ibcCfg.chainB.(*cosmos.Chain).GetNode().GrpcConn
+	denom := ibcCfg.chainA.GetRelayerConfig().Denom
+	amount := sdk.NewCoin(denom, sdkmath.NewInt(100_000))
+	senderWallet := ibcCfg.chainA.GetFaucetWallet()
+	receiverAddr, err := sdkacc.AddressFromWallet(ibcCfg.chainB.GetFaucetWallet())
+	require.NoError(t, err)
+
+	require.NoError(t, ibcCfg.relayer.Start(ctx))
+	t.Cleanup(func() {
+		_ = ibcCfg.relayer.Stop(ctx)
+	})
+
+	// requireRefunded waits for the transfer to be relayed and verifies that its tokens left the escrow again.
+	requireRefunded := func(t *testing.T, transfer ibc.Transfer, initialEscrowBalance, initialReceiverBalance sdkmath.Int) {
+		require.NoError(t, ibc.WaitForFlushed(ctx, connA, connB, ibcCfg.channel, 2*time.Minute))
+
+		escrowBalance, err := ibc.EscrowBalance(ctx, connA, ibcCfg.channel, denom)
+		require.NoError(t, err)
+		require.True(t, escrowBalance.Equal(initialEscrowBalance), "transfer should be refunded from escrow: expected %s, got %s", initialEscrowBalance, escrowBalance)
+
+		receiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, transfer.ReceivedDenom)
+		require.True(t, receiverBalance.Equal(initialReceiverBalance), "transfer should not be received")
+	}
+
+	t.Run("timeout while the relayer is stopped", func(t *testing.T) {
+		initialEscrowBalance, err := ibc.EscrowBalance(ctx, connA, ibcCfg.channel, denom)
+		require.NoError(t, err)
+		initialReceiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, ibc.ReceivedDenomTrace(ibcCfg.channel, denom).IBCDenom())
+
+		require.NoError(t, ibcCfg.relayer.Stop(ctx))
+
+		heightB, err := ibcCfg.chainB.Height(ctx)
+		require.NoError(t, err)
+		transfer, err := ibc.SendTransfer(ctx, ibcCfg.chainA, senderWallet, ibcCfg.channel, amount, receiverAddr.String(), ibc.TransferOptions{
+			TimeoutHeight: clienttypes.NewHeight(clienttypes.ParseChainID(ibcCfg.chainB.GetChainID()), uint64(heightB+5)),
+			Memo:          "timeout",
+		})
+		require.NoError(t, err)
+
+		escrowBalance, err := ibc.EscrowBalance(ctx, connA, ibcCfg.channel, denom)
+		require.NoError(t, err)
+		require.True(t, escrowBalance.Equal(initialEscrowBalance.Add(amount.Amount)), "transfer should be escrowed")
+
+		require.NoError(t, ibc.WaitForTimeout(ctx, ibcCfg.chainB, transfer, time.Minute))
+		require.NoError(t, ibcCfg.relayer.Start(ctx))
+
+		requireRefunded(t, transfer, initialEscrowBalance, initialReceiverBalance)
+	})
+
+	t.Run("timeout while the counterparty is paused", func(t *testing.T) {
+		initialEscrowBalance, err := ibc.EscrowBalance(ctx, connA, ibcCfg.channel, denom)
+		require.NoError(t, err)
+		initialReceiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, ibc.ReceivedDenomTrace(ibcCfg.channel, denom).IBCDenom())
+
+		counterparty := ibcCfg.chainB.(*cosmos.Chain)
+		require.NoError(t, counterparty.Pause(ctx))
+		t.Cleanup(func() {
+			_ = counterparty.Resume(ctx)
+		})
+
+		transfer, err := ibc.SendTransfer(ctx, ibcCfg.chainA, senderWallet, ibcCfg.channel, amount, receiverAddr.String(), ibc.TransferOptions{
+			TimeoutTimestamp: time.Now().Add(15 * time.Second),
+			Memo:             "timeout",
+		})
+		require.NoError(t, err)
+
+		// the counterparty produces no blocks while paused, its first block once resumed is past the timeout.
+		time.Sleep(time.Until(transfer.TimeoutTimestamp))
+		require.NoError(t, counterparty.Resume(ctx))
+		require.NoError(t, ibc.WaitForTimeout(ctx, ibcCfg.chainB, transfer, time.Minute))
+
+		requireRefunded(t, transfer, initialEscrowBalance, initialReceiverBalance)
+	})
+
+	t.Run("error acknowledgement", func(t *testing.T) {
+		initialEscrowBalance, err := ibc.EscrowBalance(ctx, connA, ibcCfg.channel, denom)
+		require.NoError(t, err)
+		initialReceiverBalance := getBalance(t, ctx, ibcCfg.chainB, receiverAddr, ibc.ReceivedDenomTrace(ibcCfg.channel, denom).IBCDenom())
+
+		// the receiver is only validated on the receiving chain, which acknowledges the packet with an error.
+		transfer, err := ibc.SendTransfer(ctx, ibcCfg.chainA, senderWallet, ibcCfg.channel, amount, "not-an-address", ibc.TransferOptions{})
+		require.NoError(t, err)
+
+		requireRefunded(t, transfer, initialEscrowBalance, initialReceiverBalance)
+	})
+}
+
+// TestReceivedDenomTrace verifies that tokens are prefixed with the receiving channel and unwound when returning.
+func TestReceivedDenomTrace(t *testing.T) {
+	channel := ibc.Channel{PortID: "transfer", ChannelID: "channel-0", CounterpartyPort: "transfer", CounterpartyID: "channel-7"}
+
+	received := ibc.ReceivedDenomTrace(channel, "utia")
+	require.Equal(t, "transfer/channel-7/utia", received.GetFullDenomPath())
+
+	returned := ibc.ReceivedDenomTrace(channel.Counterparty(), received.GetFullDenomPath())
+	require.Equal(t, "utia", returned.IBCDenom())
+
+	forwarded := ibc.ReceivedDenomTrace(channel, "transfer/channel-3/stake")
+	require.Equal(t, "transfer/channel-7/transfer/channel-3/stake", forwarded.GetFullDenomPath())
+}
+
 // getBalance queries the balance of an address for a specific denom
 func getBalance(t *testing.T, ctx context.Context, chain types.Chain, address sdk.AccAddress, denom string) sdkmath.Int {
 	// Get the first node to create a client context
@@ -209,13 +343,3 @@ func getBalance(t *testing.T, ctx context.Context, chain types.Chain, address sd
 	}
 	return amount
 }
-
-// calculateIBCDenom calculates the IBC denomination for a token transferred over IBC
-func calculateIBCDenom(portID, channelID, baseDenom string) string {
-	prefixedDenom := ibctransfertypes.GetPrefixedDenom(
-		portID,
-		channelID,
-		baseDenom,
-	)
-	return ibctransfertypes.ParseDenomTrace(prefixedDenom).IBCDenom()
-}
@@ -0,0 +1,83 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	"github.com/celestiaorg/tastora/framework/docker/ibc"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInterchainAccounts verifies that an interchain account can be registered by simapp on celestia-app, and
+// that the transactions it sends are executed on celestia-app.
+func TestInterchainAccounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	ibcCfg := setupIBCDockerTest(t)
+	ctx := ibcCfg.Ctx
+
+	// the relayer completes the handshake of the interchain account channel and relays its packets.
+	require.NoError(t, ibcCfg.relayer.Start(ctx))
+	t.Cleanup(func() {
+		_ = ibcCfg.relayer.Stop(ctx)
+	})
+
+	controller, host := ibcCfg.chainB, ibcCfg.chainA
+	controllerConn := controller.(*cosmos.Chain).GetNode().GrpcConn
+	hostDenom := host.GetRelayerConfig().Denom
+	owner := controller.GetFaucetWallet()
+
+	account, err := ibc.RegisterInterchainAccount(ctx, controller, owner, ibcCfg.connection.CounterpartyID, ibc.InterchainAccountOptions{})
+	require.NoError(t, err)
+
+	account, err = ibc.WaitForInterchainAccount(ctx, controllerConn, account, 2*time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, account.Address)
+	require.NotEmpty(t, account.Channel.CounterpartyID)
+	t.Logf("Registered interchain account %s on channel %s", account.Address, account.Channel.ChannelID)
+
+	icaAddr, err := sdk.AccAddressFromBech32(account.Address)
+	require.NoError(t, err)
+	hostFaucetAddr, err := sdkacc.AddressFromWallet(host.GetFaucetWallet())
+	require.NoError(t, err)
+
+	funding := sdk.NewCoins(sdk.NewCoin(hostDenom, sdkmath.NewInt(1_000_000)))
+	resp, err := host.BroadcastMessages(ctx, host.GetFaucetWallet(), banktypes.NewMsgSend(hostFaucetAddr, icaAddr, funding))
+	require.NoError(t, err)
+	require.Zero(t, resp.Code, resp.RawLog)
+
+	recipient, err := host.CreateWallet(ctx, "ica-recipient")
+	require.NoError(t, err)
+	recipientAddr, err := sdkacc.AddressFromWallet(recipient)
+	require.NoError(t, err)
+
+	t.Run("executes messages on the host", func(t *testing.T) {
+		amount := sdk.NewCoins(sdk.NewCoin(hostDenom, sdkmath.NewInt(1_000)))
+		result, err := ibc.ExecuteInterchainTx(ctx, controller, host, owner, account, time.Minute,
+			banktypes.NewMsgSend(icaAddr, recipientAddr, amount))
+		require.NoError(t, err)
+		require.True(t, result.Success(), "interchain account tx failed: %s", result.Error)
+		require.Len(t, result.MsgResponses, 1)
+		require.Equal(t, sdk.MsgTypeURL(&banktypes.MsgSendResponse{}), result.MsgResponses[0].TypeUrl)
+
+		balance := getBalance(t, ctx, host, recipientAddr, hostDenom)
+		require.True(t, balance.Equal(amount.AmountOf(hostDenom)), "recipient should receive %s, got %s", amount, balance)
+	})
+
+	t.Run("returns host errors", func(t *testing.T) {
+		tooMuch := sdk.NewCoins(sdk.NewCoin(hostDenom, funding.AmountOf(hostDenom).MulRaw(2)))
+		result, err := ibc.ExecuteInterchainTx(ctx, controller, host, owner, account, time.Minute,
+			banktypes.NewMsgSend(icaAddr, recipientAddr, tooMuch))
+		require.NoError(t, err)
+		require.False(t, result.Success(), "sending more than the balance of the interchain account should fail")
+		require.NotEmpty(t, result.Error)
+	})
+}
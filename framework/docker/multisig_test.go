@@ -0,0 +1,125 @@
+package docker
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/testutil/wallet"
+	"github.com/celestiaorg/tastora/framework/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMultisigAndOfflineSigning verifies that a 2-of-3 multisig account can send funds once enough members
+// have signed, and that a transaction can be generated, signed and broadcast in separate steps.
+func TestMultisigAndOfflineSigning(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	denom := chain.Config.Denom
+	alice, err := chain.CreateWallet(testCfg.Ctx, "alice")
+	require.NoError(t, err)
+	bob, err := chain.CreateWallet(testCfg.Ctx, "bob")
+	require.NoError(t, err)
+	carol, err := chain.CreateWallet(testCfg.Ctx, "carol")
+	require.NoError(t, err)
+	receiver, err := chain.CreateWallet(testCfg.Ctx, "receiver")
+	require.NoError(t, err)
+
+	multisigWallet, err := chain.CreateMultisigWallet(testCfg.Ctx, "multisig", 2, alice, bob, carol)
+	require.NoError(t, err)
+	require.Equal(t, 2, multisigWallet.Threshold())
+
+	faucetAddr, err := sdkacc.AddressFromWallet(chain.GetFaucetWallet())
+	require.NoError(t, err)
+	multisigAddr, err := sdkacc.AddressFromWallet(multisigWallet.Wallet)
+	require.NoError(t, err)
+	toAddr, err := sdkacc.AddressFromWallet(receiver)
+	require.NoError(t, err)
+
+	fund := banktypes.NewMsgSend(faucetAddr, multisigAddr, sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(10_000_000))))
+	resp, err := chain.BroadcastMessages(testCfg.Ctx, chain.GetFaucetWallet(), fund)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+	sendAmount := sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(1_000)))
+
+	t.Run("below threshold", func(t *testing.T) {
+		txJSON, err := chain.GenerateTx(testCfg.Ctx, banktypes.NewMsgSend(multisigAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+
+		sig, err := chain.SignMultisigTx(testCfg.Ctx, multisigWallet, alice, txJSON)
+		require.NoError(t, err)
+
+		_, err = chain.CombineMultisigTx(testCfg.Ctx, multisigWallet, txJSON, sig)
+		require.Error(t, err)
+	})
+
+	t.Run("combine partial signatures", func(t *testing.T) {
+		txJSON, err := chain.GenerateTx(testCfg.Ctx, banktypes.NewMsgSend(multisigAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+
+		// members sign independently and in any order.
+		var sigs []signing.SignatureV2
+		for _, member := range []*types.Wallet{carol, alice} {
+			sig, err := chain.SignMultisigTx(testCfg.Ctx, multisigWallet, member, txJSON)
+			require.NoError(t, err)
+			sigs = append(sigs, sig)
+		}
+
+		signedTx, err := chain.CombineMultisigTx(testCfg.Ctx, multisigWallet, txJSON, sigs...)
+		require.NoError(t, err)
+
+		resp, err := chain.BroadcastTx(testCfg.Ctx, signedTx)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+	})
+
+	t.Run("broadcast multisig messages", func(t *testing.T) {
+		resp, err := chain.BroadcastMultisigMessages(testCfg.Ctx, multisigWallet, []*types.Wallet{bob, carol}, banktypes.NewMsgSend(multisigAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		balance, err := query.Balance(testCfg.Ctx, chain.GetNode().GrpcConn, receiver.GetFormattedAddress(), denom)
+		require.NoError(t, err)
+		require.True(t, balance.Equal(sdkmath.NewInt(2_000)))
+	})
+
+	t.Run("generate, sign and broadcast", func(t *testing.T) {
+		sender, err := wallet.CreateAndFund(testCfg.Ctx, "offline-sender", sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(10_000_000))), chain)
+		require.NoError(t, err)
+		fromAddr, err := sdkacc.AddressFromWallet(sender)
+		require.NoError(t, err)
+
+		txJSON, err := chain.GenerateTx(testCfg.Ctx, banktypes.NewMsgSend(fromAddr, toAddr, sendAmount))
+		require.NoError(t, err)
+
+		// an unsigned transaction is rejected.
+		_, err = chain.BroadcastTx(testCfg.Ctx, txJSON)
+		require.Error(t, err)
+
+		signedTx, err := chain.SignTx(testCfg.Ctx, sender, txJSON)
+		require.NoError(t, err)
+
+		resp, err := chain.BroadcastTx(testCfg.Ctx, signedTx)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		balance, err := query.Balance(testCfg.Ctx, chain.GetNode().GrpcConn, receiver.GetFormattedAddress(), denom)
+		require.NoError(t, err)
+		require.True(t, balance.Equal(sdkmath.NewInt(3_000)))
+	})
+}
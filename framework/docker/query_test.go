@@ -0,0 +1,117 @@
+package docker
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueries verifies the query helpers against a running chain, including height pinned queries.
+func TestQueries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	ctx := testCfg.Ctx
+	conn := chain.GetNode().GrpcConn
+	denom := chain.Config.Denom
+
+	receiver, err := chain.CreateWallet(ctx, "receiver")
+	require.NoError(t, err)
+
+	faucetAddr, err := sdkacc.AddressFromWallet(chain.GetFaucetWallet())
+	require.NoError(t, err)
+	toAddr, err := sdkacc.AddressFromWallet(receiver)
+	require.NoError(t, err)
+	sendAmount := sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(1_000)))
+	resp, err := chain.BroadcastMessages(ctx, chain.GetFaucetWallet(), banktypes.NewMsgSend(faucetAddr, toAddr, sendAmount))
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+	t.Run("bank", func(t *testing.T) {
+		balances, err := query.AllBalances(ctx, conn, receiver.GetFormattedAddress())
+		require.NoError(t, err)
+		require.Equal(t, sendAmount, balances)
+
+		// the receiver had no balance before the transfer.
+		balances, err = query.AllBalances(query.AtHeight(ctx, resp.Height-1), conn, receiver.GetFormattedAddress())
+		require.NoError(t, err)
+		require.True(t, balances.IsZero())
+
+		supply, err := query.SupplyOf(ctx, conn, denom)
+		require.NoError(t, err)
+		require.True(t, supply.IsPositive())
+
+		total, err := query.TotalSupply(ctx, conn)
+		require.NoError(t, err)
+		require.True(t, total.AmountOf(denom).Equal(supply.Amount))
+	})
+
+	t.Run("auth", func(t *testing.T) {
+		account, err := query.Account(ctx, conn, chain.Config.EncodingConfig.InterfaceRegistry, chain.GetFaucetWallet().GetFormattedAddress())
+		require.NoError(t, err)
+		require.Equal(t, chain.GetFaucetWallet().GetFormattedAddress(), account.GetAddress().String())
+		require.Positive(t, account.GetSequence())
+	})
+
+	t.Run("staking and distribution", func(t *testing.T) {
+		validators, err := query.Validators(ctx, conn, stakingtypes.BondStatusBonded)
+		require.NoError(t, err)
+		require.Len(t, validators, len(chain.Validators))
+
+		delegations, err := query.ValidatorDelegations(ctx, conn, validators[0].OperatorAddress)
+		require.NoError(t, err)
+		require.NotEmpty(t, delegations)
+
+		delegator := delegations[0].Delegation.DelegatorAddress
+		delegation, err := query.Delegation(ctx, conn, delegator, validators[0].OperatorAddress)
+		require.NoError(t, err)
+		require.True(t, delegation.Balance.IsPositive())
+
+		_, total, err := query.DelegationTotalRewards(ctx, conn, delegator)
+		require.NoError(t, err)
+		require.NotNil(t, total)
+	})
+
+	t.Run("gov and upgrade", func(t *testing.T) {
+		proposals, err := query.Proposals(ctx, conn, govv1.StatusNil)
+		require.NoError(t, err)
+		require.Empty(t, proposals)
+
+		plan, err := query.CurrentPlan(ctx, conn)
+		require.NoError(t, err)
+		require.Nil(t, plan)
+	})
+
+	t.Run("ibc", func(t *testing.T) {
+		clients, err := query.ClientStates(ctx, conn)
+		require.NoError(t, err)
+		require.Empty(t, clients)
+
+		channels, err := query.Channels(ctx, conn)
+		require.NoError(t, err)
+		require.Empty(t, channels)
+	})
+
+	t.Run("blob params", func(t *testing.T) {
+		params, err := query.BlobModuleParams(ctx, conn)
+		require.NoError(t, err)
+		require.NotZero(t, params.GasPerBlobByte)
+		require.NotZero(t, params.GovMaxSquareSize)
+	})
+}
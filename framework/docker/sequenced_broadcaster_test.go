@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"sync"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	"github.com/celestiaorg/tastora/framework/testutil/query"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/testutil/wallet"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSequencedBroadcaster verifies that concurrent transactions from a single wallet are broadcast with
+// distinct sequences without waiting for each other, and that the sequence is resynced when the wallet is
+// used by another broadcaster.
+func TestSequencedBroadcaster(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	denom := chain.Config.Denom
+	sender, err := wallet.CreateAndFund(testCfg.Ctx, "sender", sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(1_000_000_000))), chain)
+	require.NoError(t, err)
+	receiver, err := chain.CreateWallet(testCfg.Ctx, "receiver")
+	require.NoError(t, err)
+
+	fromAddr, err := sdkacc.AddressFromWallet(sender)
+	require.NoError(t, err)
+	toAddr, err := sdkacc.AddressFromWallet(receiver)
+	require.NoError(t, err)
+	sendAmount := sdk.NewCoins(sdk.NewCoin(denom, sdkmath.NewInt(1_000)))
+	newSend := func() sdk.Msg { return banktypes.NewMsgSend(fromAddr, toAddr, sendAmount) }
+
+	b := cosmos.NewSequencedBroadcaster(chain, nil)
+
+	const numTxs = 20
+	t.Run("concurrent submissions", func(t *testing.T) {
+		hashes := make([]string, numTxs)
+		errs := make([]error, numTxs)
+		var wg sync.WaitGroup
+		for i := range numTxs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				hashes[i], errs[i] = b.Submit(testCfg.Ctx, sender, newSend())
+			}()
+		}
+		wg.Wait()
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+
+		responses, err := b.Confirm(testCfg.Ctx, hashes...)
+		require.NoError(t, err)
+		for _, resp := range responses {
+			require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+		}
+
+		balance, err := query.Balance(testCfg.Ctx, chain.GetNode().GrpcConn, receiver.GetFormattedAddress(), denom)
+		require.NoError(t, err)
+		require.True(t, balance.Equal(sdkmath.NewInt(numTxs*1_000)))
+	})
+
+	t.Run("resync after external transaction", func(t *testing.T) {
+		// bump the sequence of the sender behind the sequenced broadcaster's back.
+		_, err := chain.BroadcastMessages(testCfg.Ctx, sender, newSend())
+		require.NoError(t, err)
+
+		resp, err := b.BroadcastMessages(testCfg.Ctx, sender, newSend())
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+	})
+
+	t.Run("async mode", func(t *testing.T) {
+		async := cosmos.NewSequencedBroadcaster(chain, nil).WithBroadcastMode(cosmos.BroadcastModeAsync)
+		var hashes []string
+		for range 5 {
+			hash, err := async.Submit(testCfg.Ctx, sender, newSend())
+			require.NoError(t, err)
+			require.NotEmpty(t, hash)
+			hashes = append(hashes, hash)
+		}
+
+		responses, err := async.Confirm(testCfg.Ctx, hashes...)
+		require.NoError(t, err)
+		require.Len(t, responses, len(hashes))
+		for _, resp := range responses {
+			require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+		}
+	})
+}
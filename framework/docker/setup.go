@@ -9,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/celestiaorg/tastora/framework/docker/artifacts"
+	"github.com/celestiaorg/tastora/framework/docker/chaos"
 	tastoraclient "github.com/celestiaorg/tastora/framework/docker/client"
 	"github.com/celestiaorg/tastora/framework/docker/consts"
 	"github.com/celestiaorg/tastora/framework/testutil/random"
@@ -21,6 +23,7 @@ import (
 )
 
 // SetupTestingT is a subset of testing.T required for Setup.
+// Every types.TestingT, including types.StandaloneT, satisfies it.
 type SetupTestingT interface {
 	Helper()
 
@@ -66,6 +69,8 @@ func Setup(t SetupTestingT) (types.TastoraDockerClient, string) {
 		panic(fmt.Errorf("failed to create docker network: %v", err))
 	}
 
+	// cleanups run in the reverse order of registration, so sources are forgotten after the docker cleanup.
+	artifacts.ForgetOnCleanup(t, cleanupLabel)
 	t.Cleanup(Cleanup(t, dockerClient))
 
 	return dockerClient, network.ID
@@ -84,6 +89,25 @@ func CleanupWithLabel(t SetupTestingT, cli types.TastoraDockerClient, cleanupLab
 		keepContainers := os.Getenv("KEEP_CONTAINERS") != ""
 
 		ctx := context.TODO()
+
+		// heal any network faults first so that containers which are kept, or whose volumes
+		// are reused, are not left partitioned or degraded.
+		if err := chaos.HealAll(ctx, cleanupLabel); err != nil {
+			t.Logf("Failed to heal network faults during docker cleanup: %v", err)
+		}
+
+		// artifacts are collected before any container is stopped so that nodes can still report their heights.
+		if dir := artifacts.Dir(t.Failed()); dir != "" {
+			testDir, err := artifacts.Collect(ctx, cli, cleanupLabel, dir, t.Name(), t.Failed())
+			if err != nil {
+				t.Logf("Failed to collect some artifacts during docker cleanup: %v", err)
+			}
+			if testDir != "" {
+				t.Logf("Wrote artifacts to %s", testDir)
+			}
+		}
+		artifacts.Forget(cleanupLabel)
+
 		cs, err := cli.ContainerList(ctx, client.ContainerListOptions{
 			All:     true,
 			Filters: make(client.Filters).Add("label", consts.CleanupLabel+"="+cleanupLabel),
@@ -0,0 +1,253 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/docker/volume"
+	"github.com/celestiaorg/tastora/framework/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// manifestName is the name of the manifest entry within a snapshot archive.
+	manifestName = "manifest.json"
+	// volumesDir is the directory within a snapshot archive containing one tar entry per volume.
+	volumesDir = "volumes"
+)
+
+// Volume maps a stable key to a docker volume. Keys identify the same logical node across topologies,
+// whereas volume names depend on the test that created them.
+type Volume struct {
+	// Key identifies the volume within the snapshot, e.g. "celestia-app/0".
+	Key string
+	// Name is the name of the docker volume.
+	Name string
+}
+
+// Manifest describes the contents of a snapshot.
+type Manifest struct {
+	// CreatedAt is the time the snapshot was taken.
+	CreatedAt time.Time `json:"created_at"`
+	// Volumes are the keys of every volume stored in the snapshot.
+	Volumes []string `json:"volumes"`
+	// Metadata holds arbitrary values required to restore the topology, e.g. the chain ID.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Config contains the configuration for saving and loading snapshots.
+type Config struct {
+	// Logger is the logger instance used for all operations.
+	Logger *zap.Logger
+	// DockerClient is the docker client instance.
+	DockerClient types.TastoraDockerClient
+}
+
+// Save writes the contents of every volume, along with the provided metadata, into a gzipped tarball at filePath.
+// Containers writing to the volumes should be stopped or paused for the snapshot to be consistent.
+func Save(ctx context.Context, cfg Config, filePath string, volumes []Volume, metadata map[string]string) (err error) {
+	manifest := Manifest{
+		CreatedAt: time.Now().UTC(),
+		Metadata:  metadata,
+	}
+	seen := make(map[string]struct{}, len(volumes))
+	for _, v := range volumes {
+		if _, ok := seen[v.Key]; ok {
+			return fmt.Errorf("duplicate volume key %q", v.Key)
+		}
+		seen[v.Key] = struct{}{}
+		manifest.Volumes = append(manifest.Volumes, v.Key)
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating snapshot file: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	manifestBz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling manifest: %w", err)
+	}
+	if err := writeEntry(tw, manifestName, int64(len(manifestBz)), bytes.NewReader(manifestBz)); err != nil {
+		return err
+	}
+
+	for _, v := range volumes {
+		if err := saveVolume(ctx, cfg, tw, v); err != nil {
+			return fmt.Errorf("saving volume %s: %w", v.Key, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing tar writer: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("closing gzip writer: %w", err)
+	}
+
+	cfg.Logger.Info("saved snapshot", zap.String("path", filePath), zap.Strings("volumes", manifest.Volumes))
+	return nil
+}
+
+// saveVolume exports a volume to a temporary file, as the size of each entry must be known
+// before it can be written to the snapshot archive.
+func saveVolume(ctx context.Context, cfg Config, tw *tar.Writer, v Volume) error {
+	tmp, err := os.CreateTemp("", "tastora-volume-*.tar")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := volume.Export(ctx, volume.ArchiveOptions{
+		Log:        cfg.Logger,
+		Client:     cfg.DockerClient,
+		VolumeName: v.Name,
+	}, tmp); err != nil {
+		return err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("determining archive size: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewinding archive: %w", err)
+	}
+
+	return writeEntry(tw, volumeEntryName(v.Key), size, tmp)
+}
+
+// Load restores every volume from the snapshot at filePath into the docker volume with the matching key.
+// Every provided volume must be present in the snapshot. Volumes in the snapshot which are not provided are skipped.
+func Load(ctx context.Context, cfg Config, filePath string, volumes []Volume) (Manifest, error) {
+	targets := make(map[string]string, len(volumes))
+	for _, v := range volumes {
+		targets[volumeEntryName(v.Key)] = v.Name
+	}
+
+	var manifest Manifest
+	restored := 0
+	err := walk(filePath, func(hdr *tar.Header, r io.Reader) error {
+		if hdr.Name == manifestName {
+			return json.NewDecoder(r).Decode(&manifest)
+		}
+
+		volumeName, ok := targets[hdr.Name]
+		if !ok {
+			return nil
+		}
+
+		if err := volume.Import(ctx, volume.ArchiveOptions{
+			Log:        cfg.Logger,
+			Client:     cfg.DockerClient,
+			VolumeName: volumeName,
+		}, r); err != nil {
+			return fmt.Errorf("restoring %s: %w", hdr.Name, err)
+		}
+		restored++
+		return nil
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	if restored != len(volumes) {
+		return Manifest{}, fmt.Errorf("snapshot %s contains %d of the %d requested volumes (available: %v)", filePath, restored, len(volumes), manifest.Volumes)
+	}
+
+	cfg.Logger.Info("loaded snapshot", zap.String("path", filePath), zap.Int("volumes", restored))
+	return manifest, nil
+}
+
+// ReadManifest returns the manifest of the snapshot at filePath without restoring any volumes.
+func ReadManifest(filePath string) (Manifest, error) {
+	var manifest Manifest
+	found := false
+	err := walk(filePath, func(hdr *tar.Header, r io.Reader) error {
+		if hdr.Name != manifestName {
+			return nil
+		}
+		found = true
+		return json.NewDecoder(r).Decode(&manifest)
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+	if !found {
+		return Manifest{}, fmt.Errorf("snapshot %s does not contain a manifest", filePath)
+	}
+	return manifest, nil
+}
+
+// walk invokes fn for every entry in the snapshot archive at filePath.
+func walk(filePath string, fn func(hdr *tar.Header, r io.Reader) error) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("opening snapshot file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("reading snapshot file: %w", err)
+	}
+	defer func() {
+		_ = gr.Close()
+	}()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading snapshot archive: %w", err)
+		}
+		if err := fn(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// writeEntry writes a single regular file entry to the archive.
+func writeEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Size:    size,
+		Mode:    0o600,
+		ModTime: time.Now(),
+		Format:  tar.FormatPAX,
+	}); err != nil {
+		return fmt.Errorf("writing tar header for %s: %w", name, err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("writing %s to tar: %w", name, err)
+	}
+	return nil
+}
+
+// volumeEntryName returns the name of the archive entry holding the volume with the given key.
+func volumeEntryName(key string) string {
+	return path.Join(volumesDir, key+".tar")
+}
@@ -0,0 +1,48 @@
+package snapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSaveAndReadManifest(t *testing.T) {
+	cfg := Config{Logger: zaptest.NewLogger(t)}
+	filePath := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+
+	metadata := map[string]string{"chain_id": "test-chain"}
+	require.NoError(t, Save(context.Background(), cfg, filePath, nil, metadata))
+
+	manifest, err := ReadManifest(filePath)
+	require.NoError(t, err)
+	require.Equal(t, metadata, manifest.Metadata)
+	require.Empty(t, manifest.Volumes)
+	require.False(t, manifest.CreatedAt.IsZero())
+
+	loaded, err := Load(context.Background(), cfg, filePath, nil)
+	require.NoError(t, err)
+	require.Equal(t, manifest, loaded)
+}
+
+func TestSaveDuplicateKeys(t *testing.T) {
+	cfg := Config{Logger: zaptest.NewLogger(t)}
+	filePath := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+
+	err := Save(context.Background(), cfg, filePath, []Volume{
+		{Key: "node/0", Name: "vol-a"},
+		{Key: "node/0", Name: "vol-b"},
+	}, nil)
+	require.ErrorContains(t, err, "duplicate volume key")
+}
+
+func TestLoadMissingVolume(t *testing.T) {
+	cfg := Config{Logger: zaptest.NewLogger(t)}
+	filePath := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	require.NoError(t, Save(context.Background(), cfg, filePath, nil, nil))
+
+	_, err := Load(context.Background(), cfg, filePath, []Volume{{Key: "node/0", Name: "vol"}})
+	require.ErrorContains(t, err, "contains 0 of the 1 requested volumes")
+}
@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/testutil/deploy"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnapshotRestore verifies that a stack can be restored from a snapshot into new containers
+// and continues from the height at which the snapshot was taken.
+func TestSnapshotRestore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	stack, err := deploy.WithDefaults(t, testCfg.DockerClient, testCfg.NetworkID, testCfg.TestName)
+	require.NoError(t, err)
+
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 3, stack.Celestia))
+
+	// the snapshot contains at least every block committed before it was taken.
+	snapshotHeight, err := stack.Celestia.Height(testCfg.Ctx)
+	require.NoError(t, err)
+
+	snapshotPath := filepath.Join(t.TempDir(), "stack.tar.gz")
+	require.NoError(t, deploy.Snapshot(testCfg.Ctx, stack, snapshotPath))
+
+	// the original stack keeps running after the snapshot.
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, stack.Celestia))
+
+	// remove the original stack so the restored one is the only one producing blocks.
+	require.NoError(t, stack.EVM.Remove(testCfg.Ctx))
+	require.NoError(t, stack.Reth.Remove(testCfg.Ctx))
+	require.NoError(t, stack.DA.Remove(testCfg.Ctx))
+	require.NoError(t, stack.Celestia.Remove(testCfg.Ctx))
+
+	restored, err := deploy.RestoreWithDefaults(t, testCfg.DockerClient, testCfg.NetworkID, testCfg.TestName+"-restored", snapshotPath)
+	require.NoError(t, err)
+
+	restoredHeight, err := restored.Celestia.Height(testCfg.Ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, restoredHeight, snapshotHeight, "restored chain should continue from the snapshot height")
+
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, restored.Celestia))
+
+	bridge := restored.DA.GetBridgeNodes()[0]
+	require.NoError(t, wait.ForDANodeToReachHeight(testCfg.Ctx, bridge, uint64(restoredHeight), time.Minute))
+}
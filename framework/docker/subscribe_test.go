@@ -0,0 +1,105 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/testutil/events"
+	"github.com/celestiaorg/tastora/framework/testutil/sdkacc"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChainNodeSubscriptions verifies that new blocks and transactions are received through subscriptions,
+// and that subscriptions survive the node's container being recreated.
+func TestChainNodeSubscriptions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+	configureBech32PrefixOnce()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	node := chain.GetNode()
+
+	t.Run("new blocks", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, time.Minute)
+		defer cancel()
+
+		blocks, err := node.SubscribeNewBlocks(ctx)
+		require.NoError(t, err)
+
+		var last int64
+		for range 3 {
+			b, ok := <-blocks
+			require.True(t, ok)
+			if last != 0 {
+				require.Equal(t, last+1, b.Block.Height)
+			}
+			last = b.Block.Height
+		}
+	})
+
+	t.Run("txs", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, time.Minute)
+		defer cancel()
+
+		receiver, err := chain.CreateWallet(ctx, "receiver")
+		require.NoError(t, err)
+		faucetAddr, err := sdkacc.AddressFromWallet(chain.GetFaucetWallet())
+		require.NoError(t, err)
+		toAddr, err := sdkacc.AddressFromWallet(receiver)
+		require.NoError(t, err)
+
+		query, err := events.TxQuery(banktypes.EventTypeTransfer, events.Attr(banktypes.AttributeKeyRecipient, receiver.GetFormattedAddress()))
+		require.NoError(t, err)
+		txs, err := node.SubscribeTxs(ctx, query)
+		require.NoError(t, err)
+
+		resp, err := chain.BroadcastMessages(ctx, chain.GetFaucetWallet(), banktypes.NewMsgSend(faucetAddr, toAddr, sdk.NewCoins(sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(1_000)))))
+		require.NoError(t, err)
+
+		tx, ok := <-txs
+		require.True(t, ok)
+		require.Equal(t, resp.Height, tx.Height)
+	})
+
+	t.Run("resubscribes after restart", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(testCfg.Ctx, 5*time.Minute)
+		defer cancel()
+
+		heights, err := node.SubscribeHeights(ctx)
+		require.NoError(t, err)
+
+		// recreate the node's container with the same version, which changes its host ports.
+		require.NoError(t, chain.UpgradeNodes(ctx, chain.Config.Image.Version, node))
+
+		restarted, err := node.Height(ctx)
+		require.NoError(t, err)
+		for h := range heights {
+			if h > restarted {
+				return
+			}
+		}
+		t.Fatal("subscription closed before receiving blocks after the restart")
+	})
+
+	t.Run("wait for blocks", func(t *testing.T) {
+		start, err := chain.Height(testCfg.Ctx)
+		require.NoError(t, err)
+		require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+		end, err := chain.Height(testCfg.Ctx)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, end-start, int64(2))
+	})
+}
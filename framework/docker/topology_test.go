@@ -0,0 +1,82 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/testutil/topology"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/stretchr/testify/require"
+)
+
+const testTopology = `
+chains:
+  - name: celestia
+    chain_id: topology
+    image:
+      repository: ghcr.io/celestiaorg/celestia-app
+      version: v5.0.10
+      uid_gid: "10001:10001"
+    validators: 2
+    full_nodes: 1
+    start_args: ["--force-no-bbr", "--rpc.grpc_laddr=tcp://0.0.0.0:9098", "--timeout-commit", "1s"]
+da_networks:
+  - name: da
+    chain: celestia
+    image:
+      repository: ghcr.io/celestiaorg/celestia-node
+      version: v0.26.4
+      uid_gid: "10001:10001"
+    light_nodes: 1
+reth:
+  - name: reth0
+evm_chains:
+  - name: evm0
+    reth: reth0
+    da_network: da
+`
+
+// TestTopology verifies that an environment described by a topology file is started and wired together.
+func TestTopology(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	spec, err := topology.Parse([]byte(testTopology))
+	require.NoError(t, err)
+
+	env, err := topology.Build(testCfg.Ctx, t, topology.Config{
+		Logger:          testCfg.Logger,
+		DockerClient:    testCfg.DockerClient,
+		DockerNetworkID: testCfg.NetworkID,
+		TestName:        testCfg.TestName,
+	}, spec)
+	require.NoError(t, err)
+
+	chain, err := env.Chain("celestia")
+	require.NoError(t, err)
+	require.Len(t, chain.Validators, 2)
+	require.Len(t, chain.FullNodes, 1)
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+	height, err := chain.Height(testCfg.Ctx)
+	require.NoError(t, err)
+
+	daNetwork, err := env.DANetwork("da")
+	require.NoError(t, err)
+	require.Len(t, daNetwork.GetBridgeNodes(), 1)
+	require.Len(t, daNetwork.GetLightNodes(), 1)
+	for _, n := range daNetwork.GetNodes() {
+		require.NoError(t, wait.ForDANodeToReachHeight(testCfg.Ctx, n, uint64(height), 2*time.Minute))
+	}
+
+	evmChain, err := env.EVMChain("evm0")
+	require.NoError(t, err)
+	require.Len(t, evmChain.Nodes(), 1)
+
+	_, err = env.Chain("missing")
+	require.Error(t, err)
+}
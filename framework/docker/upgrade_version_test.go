@@ -2,6 +2,7 @@ package docker
 
 import (
 	"github.com/celestiaorg/tastora/framework/docker/container"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
 	"github.com/celestiaorg/tastora/framework/testutil/wait"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -44,3 +45,92 @@ func TestUpgradeVersion(t *testing.T) {
 	require.Equal(t, "5.0.10", abciInfo.Response.GetVersion(), "version mismatch")
 	require.Equal(t, uint64(5), abciInfo.Response.GetAppVersion(), "app_version mismatch")
 }
+
+// TestUpgradeNodes verifies that a subset of validators can be upgraded one at a time while the
+// chain keeps producing blocks with a mixed-version validator set.
+func TestUpgradeNodes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.
+		WithImage(container.NewImage("ghcr.io/celestiaorg/celestia-app", "v5.0.9", "10001:10001")).
+		WithNodes(
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().Build(),
+		).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+
+	err = chain.Start(testCfg.Ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+	// upgrade all but the first validator.
+	upgraded := chain.Validators[1:]
+	err = chain.UpgradeNodes(testCfg.Ctx, "v5.0.10", upgraded...)
+	require.NoError(t, err)
+
+	// chain keeps producing blocks with mixed versions.
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+	expectedVersions := []string{"5.0.9", "5.0.10", "5.0.10"}
+	for i, node := range chain.Validators {
+		abciInfo, err := node.Client.ABCIInfo(testCfg.Ctx)
+		require.NoError(t, err, "failed to fetch ABCI info")
+		require.Equal(t, expectedVersions[i], abciInfo.Response.GetVersion(), "version mismatch for validator %d", i)
+	}
+}
+
+// TestSoftwareUpgrade verifies that a chain halts at the height of a governance upgrade plan which its version
+// does not handle, and keeps producing blocks once its nodes are switched to the major version which does.
+func TestSoftwareUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	chain, err := testCfg.ChainBuilder.
+		WithImage(container.NewImage("ghcr.io/celestiaorg/celestia-app", "v4.1.0", "10001:10001")).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+
+	err = chain.Start(testCfg.Ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+	height, err := chain.Height(testCfg.Ctx)
+	require.NoError(t, err)
+
+	// v4 has no handler for the v5 plan so it halts at the plan height, v5 applies the plan.
+	upgrade := cosmos.SoftwareUpgrade{
+		Name:    "v5",
+		Version: "v5.0.10",
+		Height:  height + 40,
+	}
+	require.NoError(t, chain.SoftwareUpgrade(testCfg.Ctx, upgrade))
+
+	// chain keeps producing blocks past the upgrade height at the new version.
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+
+	postUpgradeHeight, err := chain.Height(testCfg.Ctx)
+	require.NoError(t, err)
+	require.Greater(t, postUpgradeHeight, upgrade.Height)
+
+	for i, node := range chain.GetNodes() {
+		rpcClient, err := node.GetRPCClient()
+		require.NoError(t, err, "failed to get RPC client for version check")
+
+		abciInfo, err := rpcClient.ABCIInfo(testCfg.Ctx)
+		require.NoError(t, err, "failed to fetch ABCI info")
+		require.Equal(t, "5.0.10", abciInfo.Response.GetVersion(), "version mismatch for node %d", i)
+	}
+}
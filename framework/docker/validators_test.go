@@ -0,0 +1,152 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	"github.com/celestiaorg/tastora/framework/testutil/wait"
+	"github.com/celestiaorg/tastora/framework/testutil/wallet"
+	"github.com/celestiaorg/tastora/framework/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidatorSetManagement verifies that a full node can be promoted to a validator, that validators can be
+// jailed for downtime and unjailed, and that stake can be delegated, redelegated and undelegated at runtime.
+func TestValidatorSetManagement(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	// three validators so that the remaining two keep producing blocks while one of them is down.
+	chain, err := testCfg.ChainBuilder.
+		WithGenesisModifications(cosmos.FastDowntimeJailing()...).
+		WithNodes(
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().WithNodeType(types.NodeTypeConsensusFull).Build(),
+		).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	t.Run("promote full node", func(t *testing.T) {
+		fullNode := chain.FullNodes[0]
+		operator, err := chain.PromoteToValidator(testCfg.Ctx, fullNode, sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(10_000_000)))
+		require.NoError(t, err)
+		require.NotNil(t, operator)
+
+		require.Len(t, chain.Validators, 4)
+		require.Empty(t, chain.FullNodes)
+		require.True(t, fullNode.Validator)
+
+		v, err := chain.QueryValidator(testCfg.Ctx, fullNode)
+		require.NoError(t, err)
+		require.True(t, v.IsBonded())
+	})
+
+	t.Run("jail and unjail", func(t *testing.T) {
+		node := chain.Validators[1]
+		require.NoError(t, chain.JailValidator(testCfg.Ctx, node))
+
+		v, err := chain.QueryValidator(testCfg.Ctx, node)
+		require.NoError(t, err)
+		require.True(t, v.IsJailed())
+
+		info, err := chain.QuerySigningInfo(testCfg.Ctx, node)
+		require.NoError(t, err)
+		require.True(t, info.JailedUntil.After(time.Time{}), "signing info should record the jail period")
+
+		// wait for the downtime jail duration set by FastDowntimeJailing to elapse.
+		require.NoError(t, wait.ForCondition(testCfg.Ctx, time.Minute, time.Second, func() (bool, error) {
+			return time.Now().After(info.JailedUntil), nil
+		}))
+		require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+		require.NoError(t, chain.Unjail(testCfg.Ctx, node))
+	})
+
+	t.Run("delegate, redelegate and undelegate", func(t *testing.T) {
+		delegator, err := wallet.CreateAndFund(testCfg.Ctx, "delegator", sdk.NewCoins(sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(10_000_000))), chain)
+		require.NoError(t, err)
+
+		src, dst := chain.Validators[0], chain.Validators[2]
+		amount := sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(3_000_000))
+
+		resp, err := chain.Delegate(testCfg.Ctx, delegator, src, amount)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		delegation, err := chain.QueryDelegation(testCfg.Ctx, delegator, src)
+		require.NoError(t, err)
+		require.True(t, delegation.Balance.Amount.Equal(amount.Amount))
+
+		moved := sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(1_000_000))
+		resp, err = chain.Redelegate(testCfg.Ctx, delegator, src, dst, moved)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		delegation, err = chain.QueryDelegation(testCfg.Ctx, delegator, dst)
+		require.NoError(t, err)
+		require.True(t, delegation.Balance.Amount.Equal(moved.Amount))
+
+		resp, err = chain.Undelegate(testCfg.Ctx, delegator, dst, moved)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), resp.Code, resp.RawLog)
+
+		_, err = chain.QueryDelegation(testCfg.Ctx, delegator, dst)
+		require.Error(t, err, "the delegation should be removed once fully undelegated")
+	})
+}
+
+// TestDoubleSign verifies that a validator whose key is used by two nodes at once double signs, and that it is
+// tombstoned once the evidence is committed.
+func TestDoubleSign(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping due to short mode")
+	}
+	t.Parallel()
+
+	testCfg := setupDockerTest(t)
+
+	// four validators so that the others keep producing blocks once the double signer is tombstoned.
+	chain, err := testCfg.ChainBuilder.
+		WithNodes(
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().Build(),
+			cosmos.NewChainNodeConfigBuilder().Build(),
+		).
+		Build(testCfg.Ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.Start(testCfg.Ctx))
+
+	validator := chain.Validators[1]
+	privValidatorKey, err := validator.ReadPrivValidatorKey(testCfg.Ctx)
+	require.NoError(t, err)
+
+	// the second node signs with the key of the validator, conflicting with its votes.
+	err = chain.AddNode(testCfg.Ctx, cosmos.NewChainNodeConfigBuilder().
+		WithNodeType(types.NodeTypeConsensusFull).
+		WithPrivValidatorKey(privValidatorKey).
+		Build())
+	require.NoError(t, err)
+
+	require.NoError(t, wait.ForCondition(testCfg.Ctx, 3*time.Minute, 2*time.Second, func() (bool, error) {
+		info, err := chain.QuerySigningInfo(testCfg.Ctx, validator)
+		if err != nil {
+			return false, err
+		}
+		return info.Tombstoned, nil
+	}))
+
+	v, err := chain.QueryValidator(testCfg.Ctx, validator)
+	require.NoError(t, err)
+	require.True(t, v.IsJailed())
+	require.NoError(t, wait.ForBlocks(testCfg.Ctx, 2, chain))
+}
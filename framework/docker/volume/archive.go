@@ -0,0 +1,103 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/docker/consts"
+	dockerinternal "github.com/celestiaorg/tastora/framework/docker/internal"
+	"github.com/celestiaorg/tastora/framework/testutil/random"
+	"github.com/celestiaorg/tastora/framework/types"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+)
+
+// archiveMountParent is the directory the volume is mounted under while archiving.
+// The tar streams produced by Export contain every file prefixed with the base name of archiveMountPath,
+// which allows Import to extract them into archiveMountParent.
+const (
+	archiveMountParent = "/mnt"
+	archiveMountPath   = archiveMountParent + "/dockervolume"
+)
+
+// ArchiveOptions contain the configuration for the Export and Import functions.
+type ArchiveOptions struct {
+	Log        *zap.Logger
+	Client     types.TastoraDockerClient
+	VolumeName string
+}
+
+// Export writes the entire contents of a volume to w as a tar stream, preserving ownership and permissions.
+// Containers writing to the volume should be stopped or paused for the archive to be consistent.
+func Export(ctx context.Context, opts ArchiveOptions, w io.Writer) error {
+	return withArchiveContainer(ctx, opts, "volumeexport", func(containerID string) error {
+		copyResult, err := opts.Client.CopyFromContainer(ctx, containerID, client.CopyFromContainerOptions{
+			SourcePath: archiveMountPath,
+		})
+		if err != nil {
+			return fmt.Errorf("copying from container: %w", err)
+		}
+		defer func() {
+			_ = copyResult.Content.Close()
+		}()
+
+		if _, err := io.Copy(w, copyResult.Content); err != nil {
+			return fmt.Errorf("reading volume archive: %w", err)
+		}
+		return nil
+	})
+}
+
+// Import extracts a tar stream produced by Export into a volume.
+// Existing files in the volume with the same paths are overwritten.
+func Import(ctx context.Context, opts ArchiveOptions, r io.Reader) error {
+	return withArchiveContainer(ctx, opts, "volumeimport", func(containerID string) error {
+		if _, err := opts.Client.CopyToContainer(ctx, containerID, client.CopyToContainerOptions{
+			DestinationPath: archiveMountParent,
+			Content:         r,
+		}); err != nil {
+			return fmt.Errorf("copying tar to container: %w", err)
+		}
+		return nil
+	})
+}
+
+// withArchiveContainer creates a one-off container with the volume mounted, invokes fn with its ID,
+// and removes the container afterwards. The container is never started.
+func withArchiveContainer(ctx context.Context, opts ArchiveOptions, purpose string, fn func(containerID string) error) error {
+	if err := dockerinternal.EnsureBusybox(ctx, opts.Client); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("%s-%s-%d-%s", consts.CelestiaDockerPrefix, purpose, time.Now().UnixNano(), random.LowerCaseLetterString(5))
+
+	cc, err := opts.Client.ContainerCreate(ctx, client.ContainerCreateOptions{
+		Name: containerName,
+		Config: &container.Config{
+			Image: dockerinternal.BusyboxRef,
+			// Root user so that files owned by any user in the volume can be read and written.
+			User:   consts.UserRootString,
+			Labels: map[string]string{consts.CleanupLabel: opts.Client.CleanupLabel()},
+		},
+		HostConfig: &container.HostConfig{
+			Binds: []string{opts.VolumeName + ":" + archiveMountPath},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	defer func() {
+		if _, err := opts.Client.ContainerRemove(ctx, cc.ID, client.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			opts.Log.Warn("Failed to remove volume archive container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	return fn(cc.ID)
+}
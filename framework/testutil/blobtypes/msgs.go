@@ -0,0 +1,186 @@
+// Package blobtypes provides the messages of celestia-app's blob module, encoded by hand like the queries of
+// query.BlobModuleParams.
+package blobtypes
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+
+	"github.com/celestiaorg/go-square/v3/share"
+	da "github.com/celestiaorg/tastora/framework/docker/dataavailability"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/protobuf/encoding/protowire"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// RegisterInterfaces registers MsgPayForBlobs as a message, so that transactions containing it can be encoded.
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil), &MsgPayForBlobs{})
+}
+
+// MsgPayForBlobs is the celestia.blob.v1.MsgPayForBlobs message.
+type MsgPayForBlobs struct {
+	Signer           string
+	Namespaces       [][]byte
+	BlobSizes        []uint32
+	ShareCommitments [][]byte
+	ShareVersions    []uint32
+}
+
+// NewMsgPayForBlobs returns a MsgPayForBlobs paying for the blobs on behalf of the signer.
+func NewMsgPayForBlobs(signer string, blobs ...*share.Blob) (*MsgPayForBlobs, error) {
+	msg := &MsgPayForBlobs{Signer: signer}
+	for _, blob := range blobs {
+		commitment, err := da.BlobCommitment(blob)
+		if err != nil {
+			return nil, err
+		}
+		msg.Namespaces = append(msg.Namespaces, blob.Namespace().Bytes())
+		msg.BlobSizes = append(msg.BlobSizes, uint32(len(blob.Data())))
+		msg.ShareCommitments = append(msg.ShareCommitments, commitment)
+		msg.ShareVersions = append(msg.ShareVersions, uint32(blob.ShareVersion()))
+	}
+	return msg, nil
+}
+
+func (m *MsgPayForBlobs) Reset()                { *m = MsgPayForBlobs{} }
+func (m *MsgPayForBlobs) String() string        { return fmt.Sprintf("MsgPayForBlobs{%+v}", *m) }
+func (*MsgPayForBlobs) ProtoMessage()           {}
+func (*MsgPayForBlobs) XXX_MessageName() string { return "celestia.blob.v1.MsgPayForBlobs" }
+
+// Descriptor returns the gzipped file descriptor of the message, which the SDK uses to reject unknown fields
+// when decoding transactions.
+func (*MsgPayForBlobs) Descriptor() ([]byte, []int) {
+	return msgPayForBlobsFileDescriptor, []int{0}
+}
+
+// Marshal encodes repeated scalars unpacked, which every protobuf decoder accepts.
+func (m *MsgPayForBlobs) Marshal() ([]byte, error) {
+	var b []byte
+	if m.Signer != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, m.Signer)
+	}
+	for _, ns := range m.Namespaces {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, ns)
+	}
+	for _, size := range m.BlobSizes {
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(size))
+	}
+	for _, commitment := range m.ShareCommitments {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, commitment)
+	}
+	for _, version := range m.ShareVersions {
+		b = protowire.AppendTag(b, 5, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(version))
+	}
+	return b, nil
+}
+
+func (m *MsgPayForBlobs) Unmarshal(b []byte) error {
+	*m = MsgPayForBlobs{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case typ == protowire.BytesType && (num == 1 || num == 2 || num == 4):
+			var v []byte
+			v, n = protowire.ConsumeBytes(b)
+			switch num {
+			case 1:
+				m.Signer = string(v)
+			case 2:
+				m.Namespaces = append(m.Namespaces, bytes.Clone(v))
+			case 4:
+				m.ShareCommitments = append(m.ShareCommitments, bytes.Clone(v))
+			}
+		case num == 3 || num == 5:
+			var values []uint32
+			values, n = consumeUint32s(typ, b)
+			if num == 3 {
+				m.BlobSizes = append(m.BlobSizes, values...)
+			} else {
+				m.ShareVersions = append(m.ShareVersions, values...)
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// consumeUint32s decodes a repeated uint32 field encoded either packed or unpacked.
+func consumeUint32s(typ protowire.Type, b []byte) ([]uint32, int) {
+	switch typ {
+	case protowire.VarintType:
+		v, n := protowire.ConsumeVarint(b)
+		return []uint32{uint32(v)}, n
+	case protowire.BytesType:
+		packed, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return nil, n
+		}
+		var values []uint32
+		for len(packed) > 0 {
+			v, m := protowire.ConsumeVarint(packed)
+			if m < 0 {
+				return nil, m
+			}
+			values = append(values, uint32(v))
+			packed = packed[m:]
+		}
+		return values, n
+	default:
+		return nil, protowire.ConsumeFieldValue(0, typ, b)
+	}
+}
+
+// msgPayForBlobsFileDescriptor is the gzipped descriptor of a file declaring only MsgPayForBlobs.
+var msgPayForBlobsFileDescriptor = func() []byte {
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{Name: &name, Number: &num, Type: typ.Enum(), Label: label.Enum()}
+	}
+	optional, repeated := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    protov2.String("celestia/blob/v1/tx.proto"),
+		Package: protov2.String("celestia.blob.v1"),
+		Syntax:  protov2.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: protov2.String("MsgPayForBlobs"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("signer", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional),
+				field("namespaces", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, repeated),
+				field("blob_sizes", 3, descriptorpb.FieldDescriptorProto_TYPE_UINT32, repeated),
+				field("share_commitments", 4, descriptorpb.FieldDescriptorProto_TYPE_BYTES, repeated),
+				field("share_versions", 5, descriptorpb.FieldDescriptorProto_TYPE_UINT32, repeated),
+			},
+		}},
+	}
+	bz, err := protov2.Marshal(fd)
+	if err != nil {
+		panic(err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(bz); err != nil {
+		panic(err)
+	}
+	if err := zw.Close(); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}()
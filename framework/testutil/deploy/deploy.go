@@ -3,7 +3,6 @@ package deploy
 import (
 	"context"
 	"fmt"
-	"testing"
 
 	sdkmath "cosmossdk.io/math"
 	"github.com/celestiaorg/tastora/framework/docker/container"
@@ -32,10 +31,23 @@ type Stack struct {
 
 // WithDefaults deploys a celestia chain, a da network, a reth node and evm single in with default values
 // for when it is not important and that is not the focus of the test.
-func WithDefaults(t *testing.T, dockerClient types.TastoraDockerClient, networkID, testName string) (*Stack, error) {
+func WithDefaults(t types.TestingT, dockerClient types.TastoraDockerClient, networkID, testName string) (*Stack, error) {
+	t.Helper()
+	chainBuilder, daBuilder, rethBuilder, evmBuilder := defaultBuilders(t, dockerClient, networkID, testName)
+	return Deploy(context.Background(), chainBuilder, daBuilder, rethBuilder, evmBuilder)
+}
+
+// RestoreWithDefaults restores a stack deployed with WithDefaults from a snapshot created with Snapshot.
+func RestoreWithDefaults(t types.TestingT, dockerClient types.TastoraDockerClient, networkID, testName, filePath string) (*Stack, error) {
+	t.Helper()
+	chainBuilder, daBuilder, rethBuilder, evmBuilder := defaultBuilders(t, dockerClient, networkID, testName)
+	return Restore(context.Background(), filePath, chainBuilder, daBuilder, rethBuilder, evmBuilder)
+}
+
+// defaultBuilders returns the builders used to deploy a stack with default values.
+func defaultBuilders(t types.TestingT, dockerClient types.TastoraDockerClient, networkID, testName string) (*cosmos.ChainBuilder, *da.NetworkBuilder, *reth.NodeBuilder, *evmsingle.ChainBuilder) {
 	t.Helper()
 
-	ctx := context.Background()
 	logger := zaptest.NewLogger(t)
 	encConfig := testutil.MakeTestEncodingConfig(auth.AppModuleBasic{}, bank.AppModuleBasic{}, transfer.AppModuleBasic{}, govmodule.AppModuleBasic{})
 
@@ -78,7 +90,7 @@ func WithDefaults(t *testing.T, dockerClient types.TastoraDockerClient, networkI
 		WithDockerClient(dockerClient).
 		WithDockerNetworkID(networkID)
 
-	return Deploy(ctx, chainBuilder, daBuilder, rethBuilder, evmBuilder)
+	return chainBuilder, daBuilder, rethBuilder, evmBuilder
 }
 
 // Deploy deploys an ev stack with the provided set of builders. Every component is wired up together and started.
@@ -111,32 +123,14 @@ func CelestiaWithDA(ctx context.Context, chainBuilder *cosmos.ChainBuilder, daBu
 		return nil, nil, fmt.Errorf("start celestia-app: %w", err)
 	}
 
-	chainID := chain.GetChainID()
-	cni, err := chain.GetNodes()[0].GetNetworkInfo(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("chain network info: %w", err)
-	}
-	coreHost := cni.Internal.Hostname
-	coreGenesisHash, err := getGenesisHash(ctx, chain)
-	if err != nil {
-		return nil, nil, fmt.Errorf("get genesis hash: %w", err)
-	}
-
-	daNetwork, err := daBuilder.WithChainID(chainID).Build(ctx)
+	daNetwork, err := daBuilder.WithChainID(chain.GetChainID()).Build(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("build da network: %w", err)
 	}
 
 	bridge := daNetwork.GetBridgeNodes()[0]
-	if err := bridge.Start(ctx,
-		da.WithChainID(chainID),
-		da.WithAdditionalStartArguments("--p2p.network", chainID, "--core.ip", coreHost, "--rpc.addr", "0.0.0.0"),
-		da.WithEnvironmentVariables(map[string]string{
-			"CELESTIA_CUSTOM": types.BuildCelestiaCustomEnvVar(chainID, coreGenesisHash, ""),
-			"P2P_NETWORK":     chainID,
-		}),
-	); err != nil {
-		return nil, nil, fmt.Errorf("start da bridge: %w", err)
+	if err := startBridge(ctx, chain, bridge); err != nil {
+		return nil, nil, err
 	}
 
 	daWallet, err := bridge.GetWallet()
@@ -161,13 +155,6 @@ func CelestiaWithDA(ctx context.Context, chainBuilder *cosmos.ChainBuilder, daBu
 
 // RethWithEVMSingle deploys a reth node and evmsingle wired up to use the provided da network.
 func RethWithEVMSingle(ctx context.Context, rethBuilder *reth.NodeBuilder, evmBuilder *evmsingle.ChainBuilder, danet *da.Network) (*reth.Node, *evmsingle.Chain, error) {
-	bridge := danet.GetBridgeNodes()[0]
-	bridgeNodeNetworkInfo, err := bridge.GetNetworkInfo(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("bridge network info: %w", err)
-	}
-	daAddress := fmt.Sprintf("http://%s:%s", bridgeNodeNetworkInfo.Internal.IP, bridgeNodeNetworkInfo.Internal.Ports.RPC)
-
 	rnode, err := rethBuilder.Build(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("build reth: %w", err)
@@ -176,18 +163,70 @@ func RethWithEVMSingle(ctx context.Context, rethBuilder *reth.NodeBuilder, evmBu
 		return nil, nil, fmt.Errorf("start reth: %w", err)
 	}
 
+	evNodeCfg, err := evmNodeConfig(ctx, rnode, danet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	evmSingle, err := evmBuilder.WithNodes(evNodeCfg).Build(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build evm-single: %w", err)
+	}
+	if err := evmSingle.Start(ctx); err != nil {
+		return nil, nil, fmt.Errorf("start evm-single: %w", err)
+	}
+
+	return rnode, evmSingle, nil
+}
+
+// startBridge starts the bridge node connected to the provided celestia chain.
+func startBridge(ctx context.Context, chain *cosmos.Chain, bridge *da.Node) error {
+	chainID := chain.GetChainID()
+	cni, err := chain.GetNodes()[0].GetNetworkInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("chain network info: %w", err)
+	}
+	coreHost := cni.Internal.Hostname
+	coreGenesisHash, err := getGenesisHash(ctx, chain)
+	if err != nil {
+		return fmt.Errorf("get genesis hash: %w", err)
+	}
+
+	if err := bridge.Start(ctx,
+		da.WithChainID(chainID),
+		da.WithAdditionalStartArguments("--p2p.network", chainID, "--core.ip", coreHost, "--rpc.addr", "0.0.0.0"),
+		da.WithEnvironmentVariables(map[string]string{
+			"CELESTIA_CUSTOM": types.BuildCelestiaCustomEnvVar(chainID, coreGenesisHash, ""),
+			"P2P_NETWORK":     chainID,
+		}),
+	); err != nil {
+		return fmt.Errorf("start da bridge: %w", err)
+	}
+	return nil
+}
+
+// evmNodeConfig returns the configuration of an evm-single aggregator which uses the provided reth node
+// as its execution client and the bridge node of the provided da network for data availability.
+func evmNodeConfig(ctx context.Context, rnode *reth.Node, danet *da.Network) (evmsingle.NodeConfig, error) {
+	bridge := danet.GetBridgeNodes()[0]
+	bridgeNodeNetworkInfo, err := bridge.GetNetworkInfo(ctx)
+	if err != nil {
+		return evmsingle.NodeConfig{}, fmt.Errorf("bridge network info: %w", err)
+	}
+	daAddress := fmt.Sprintf("http://%s:%s", bridgeNodeNetworkInfo.Internal.IP, bridgeNodeNetworkInfo.Internal.Ports.RPC)
+
 	rni, err := rnode.GetNetworkInfo(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("reth network info: %w", err)
+		return evmsingle.NodeConfig{}, fmt.Errorf("reth network info: %w", err)
 	}
 	evmEthURL := fmt.Sprintf("http://%s:%s", rni.Internal.Hostname, rni.Internal.Ports.RPC)
 	evmEngineURL := fmt.Sprintf("http://%s:%s", rni.Internal.Hostname, rni.Internal.Ports.Engine)
 	rGenesisHash, err := rnode.GenesisHash(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("reth genesis hash: %w", err)
+		return evmsingle.NodeConfig{}, fmt.Errorf("reth genesis hash: %w", err)
 	}
 
-	evNodeCfg := evmsingle.NewNodeConfigBuilder().
+	return evmsingle.NewNodeConfigBuilder().
 		WithEVMEngineURL(evmEngineURL).
 		WithEVMETHURL(evmEthURL).
 		WithEVMJWTSecret(rnode.JWTSecretHex()).
@@ -195,17 +234,7 @@ func RethWithEVMSingle(ctx context.Context, rethBuilder *reth.NodeBuilder, evmBu
 		WithEVMBlockTime("1s").
 		WithEVMGenesisHash(rGenesisHash).
 		WithDAAddress(daAddress).
-		Build()
-
-	evmSingle, err := evmBuilder.WithNodes(evNodeCfg).Build(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("build evm-single: %w", err)
-	}
-	if err := evmSingle.Start(ctx); err != nil {
-		return nil, nil, fmt.Errorf("start evm-single: %w", err)
-	}
-
-	return rnode, evmSingle, nil
+		Build(), nil
 }
 
 func getGenesisHash(ctx context.Context, chain *cosmos.Chain) (string, error) {
@@ -0,0 +1,176 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/tastora/framework/docker/container"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	da "github.com/celestiaorg/tastora/framework/docker/dataavailability"
+	evmsingle "github.com/celestiaorg/tastora/framework/docker/evstack/evmsingle"
+	reth "github.com/celestiaorg/tastora/framework/docker/evstack/reth"
+	"github.com/celestiaorg/tastora/framework/docker/snapshot"
+)
+
+// chainIDMetadataKey is the snapshot metadata key holding the celestia chain ID.
+const chainIDMetadataKey = "chain_id"
+
+// Snapshot saves the volumes of every node in the stack, which hold the genesis, config, keyrings and
+// chain data, into a gzipped tarball at filePath.
+// All containers are paused while the volumes are archived and are resumed before returning.
+func Snapshot(ctx context.Context, stack *Stack, filePath string) (err error) {
+	nodes := stackNodes(stack)
+
+	// pause consumers before their dependencies so that no component observes another one going away.
+	var paused []*container.Node
+	defer func() {
+		for i := len(paused) - 1; i >= 0; i-- {
+			if unpauseErr := paused[i].ContainerLifecycle.UnpauseContainer(ctx); unpauseErr != nil {
+				err = errors.Join(err, fmt.Errorf("unpause node with volume %s: %w", paused[i].VolumeName, unpauseErr))
+			}
+		}
+	}()
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if err := nodes[i].ContainerLifecycle.PauseContainer(ctx); err != nil {
+			return fmt.Errorf("pause node with volume %s: %w", nodes[i].VolumeName, err)
+		}
+		paused = append(paused, nodes[i])
+	}
+
+	var volumes []snapshot.Volume
+	volumes = append(volumes, chainVolumes(stack.Celestia)...)
+	volumes = append(volumes, daVolumes(stack.DA)...)
+	volumes = append(volumes, rethVolumes(stack.Reth)...)
+	volumes = append(volumes, evmVolumes(stack.EVM)...)
+
+	return snapshot.Save(ctx, snapshotConfig(stack.Celestia), filePath, volumes, map[string]string{
+		chainIDMetadataKey: stack.Celestia.GetChainID(),
+	})
+}
+
+// Restore deploys a new ev stack from a snapshot created with Snapshot. Every component is built from the provided
+// builders, its volumes are populated from the snapshot and it is started without running init or generating genesis.
+// The builders must describe the same topology as the stack the snapshot was taken from.
+func Restore(ctx context.Context, filePath string, chainBuilder *cosmos.ChainBuilder, daBuilder *da.NetworkBuilder, rethBuilder *reth.NodeBuilder, evmBuilder *evmsingle.ChainBuilder) (*Stack, error) {
+	manifest, err := snapshot.ReadManifest(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot manifest: %w", err)
+	}
+
+	chain, err := chainBuilder.WithExistingVolumes(true).Build(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("build celestia-app: %w", err)
+	}
+	if chainID := manifest.Metadata[chainIDMetadataKey]; chainID != chain.GetChainID() {
+		return nil, fmt.Errorf("snapshot is of chain %q, but the chain builder is configured for %q", chainID, chain.GetChainID())
+	}
+
+	cfg := snapshotConfig(chain)
+	if _, err := snapshot.Load(ctx, cfg, filePath, chainVolumes(chain)); err != nil {
+		return nil, fmt.Errorf("restore celestia-app: %w", err)
+	}
+	if err := chain.Start(ctx); err != nil {
+		return nil, fmt.Errorf("start celestia-app: %w", err)
+	}
+
+	daNetwork, err := daBuilder.WithChainID(chain.GetChainID()).WithSkipInit(true).Build(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("build da network: %w", err)
+	}
+	if _, err := snapshot.Load(ctx, cfg, filePath, daVolumes(daNetwork)); err != nil {
+		return nil, fmt.Errorf("restore da network: %w", err)
+	}
+	if err := startBridge(ctx, chain, daNetwork.GetBridgeNodes()[0]); err != nil {
+		return nil, err
+	}
+
+	rnode, err := rethBuilder.Build(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("build reth: %w", err)
+	}
+	if _, err := snapshot.Load(ctx, cfg, filePath, rethVolumes(rnode)); err != nil {
+		return nil, fmt.Errorf("restore reth: %w", err)
+	}
+	if err := rnode.Start(ctx); err != nil {
+		return nil, fmt.Errorf("start reth: %w", err)
+	}
+
+	evNodeCfg, err := evmNodeConfig(ctx, rnode, daNetwork)
+	if err != nil {
+		return nil, err
+	}
+	evmSingle, err := evmBuilder.WithSkipInit(true).WithNodes(evNodeCfg).Build(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("build evm-single: %w", err)
+	}
+	if _, err := snapshot.Load(ctx, cfg, filePath, evmVolumes(evmSingle)); err != nil {
+		return nil, fmt.Errorf("restore evm-single: %w", err)
+	}
+	if err := evmSingle.Start(ctx); err != nil {
+		return nil, fmt.Errorf("start evm-single: %w", err)
+	}
+
+	return &Stack{
+		Celestia: chain,
+		DA:       daNetwork,
+		Reth:     rnode,
+		EVM:      evmSingle,
+	}, nil
+}
+
+// snapshotConfig returns the snapshot configuration sharing the docker client and logger of the chain.
+func snapshotConfig(chain *cosmos.Chain) snapshot.Config {
+	return snapshot.Config{
+		Logger:       chain.GetNode().Logger,
+		DockerClient: chain.Config.DockerClient,
+	}
+}
+
+// stackNodes returns the container of every node in the stack, ordered from dependencies to consumers.
+func stackNodes(stack *Stack) []*container.Node {
+	var nodes []*container.Node
+	for _, n := range stack.Celestia.Nodes() {
+		nodes = append(nodes, n.Node)
+	}
+	for _, n := range stack.DA.GetNodes() {
+		nodes = append(nodes, n.Node)
+	}
+	nodes = append(nodes, stack.Reth.Node)
+	for _, n := range stack.EVM.Nodes() {
+		nodes = append(nodes, n.Node)
+	}
+	return nodes
+}
+
+// chainVolumes returns the volumes of every celestia-app node keyed by position, validators first.
+func chainVolumes(chain *cosmos.Chain) []snapshot.Volume {
+	var volumes []snapshot.Volume
+	for i, n := range chain.Nodes() {
+		volumes = append(volumes, snapshot.Volume{Key: fmt.Sprintf("celestia-app/%d", i), Name: n.VolumeName})
+	}
+	return volumes
+}
+
+// daVolumes returns the volumes of every celestia-node node keyed by node type and index.
+func daVolumes(network *da.Network) []snapshot.Volume {
+	var volumes []snapshot.Volume
+	for _, n := range network.GetNodes() {
+		volumes = append(volumes, snapshot.Volume{Key: fmt.Sprintf("celestia-node/%s-%d", n.GetType().String(), n.Index), Name: n.VolumeName})
+	}
+	return volumes
+}
+
+// rethVolumes returns the volume of the reth node.
+func rethVolumes(n *reth.Node) []snapshot.Volume {
+	return []snapshot.Volume{{Key: "reth", Name: n.VolumeName}}
+}
+
+// evmVolumes returns the volumes of every evm-single node keyed by index.
+func evmVolumes(chain *evmsingle.Chain) []snapshot.Volume {
+	var volumes []snapshot.Volume
+	for _, n := range chain.Nodes() {
+		volumes = append(volumes, snapshot.Volume{Key: fmt.Sprintf("evm-single/%d", n.Index), Name: n.VolumeName})
+	}
+	return volumes
+}
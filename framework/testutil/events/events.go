@@ -0,0 +1,78 @@
+// Package events provides helpers to find and decode the events emitted by transactions, to assert the
+// result codes of transactions and to wait for events emitted in future blocks.
+package events
+
+import (
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+// Attribute is an event attribute an event has to match.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Attr returns an Attribute matching events with the given key and value.
+func Attr(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Matches returns true if the event is of the given type and has all the given attributes.
+func Matches(event abci.Event, eventType string, attrs ...Attribute) bool {
+	if event.Type != eventType {
+		return false
+	}
+	for _, want := range attrs {
+		if v, ok := AttributeOf(event, want.Key); !ok || v != want.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Find returns the events of the given type which have all the given attributes.
+func Find(events []abci.Event, eventType string, attrs ...Attribute) []abci.Event {
+	var found []abci.Event
+	for _, e := range events {
+		if Matches(e, eventType, attrs...) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// First returns the first event of the given type which has all the given attributes.
+func First(events []abci.Event, eventType string, attrs ...Attribute) (abci.Event, bool) {
+	for _, e := range events {
+		if Matches(e, eventType, attrs...) {
+			return e, true
+		}
+	}
+	return abci.Event{}, false
+}
+
+// AttributeOf returns the value of the first attribute of the event with the given key.
+func AttributeOf(event abci.Event, key string) (string, bool) {
+	for _, attr := range event.Attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// AttributeValue returns the value of the attribute with the given key of the first event of the given type
+// which has such an attribute, e.g. AttributeValue(resp.Events, "submit_proposal", "proposal_id").
+func AttributeValue(events []abci.Event, eventType, key string) (string, error) {
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		if v, ok := AttributeOf(e, key); ok {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("attribute %s of event %s not found", key, eventType)
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/require"
+)
+
+func event(eventType string, kv ...string) abci.Event {
+	e := abci.Event{Type: eventType}
+	for i := 0; i < len(kv); i += 2 {
+		e.Attributes = append(e.Attributes, abci.EventAttribute{Key: kv[i], Value: kv[i+1]})
+	}
+	return e
+}
+
+func TestFind(t *testing.T) {
+	evts := []abci.Event{
+		event("transfer", "recipient", "a", "amount", "1utia"),
+		event("message", "action", "send"),
+		event("transfer", "recipient", "b", "amount", "2utia"),
+	}
+
+	require.Len(t, Find(evts, "transfer"), 2)
+	require.Len(t, Find(evts, "transfer", Attr("recipient", "b")), 1)
+	require.Empty(t, Find(evts, "transfer", Attr("recipient", "b"), Attr("amount", "1utia")))
+
+	e, ok := First(evts, "transfer", Attr("amount", "2utia"))
+	require.True(t, ok)
+	recipient, ok := AttributeOf(e, "recipient")
+	require.True(t, ok)
+	require.Equal(t, "b", recipient)
+
+	_, ok = First(evts, "burn")
+	require.False(t, ok)
+
+	v, err := AttributeValue(evts, "message", "action")
+	require.NoError(t, err)
+	require.Equal(t, "send", v)
+
+	_, err = AttributeValue(evts, "message", "sender")
+	require.Error(t, err)
+}
+
+func TestParseTyped(t *testing.T) {
+	grant, err := sdk.TypedEventToEvent(&authz.EventGrant{MsgTypeUrl: "/cosmos.bank.v1beta1.MsgSend", Granter: "a", Grantee: "b"})
+	require.NoError(t, err)
+	revoke, err := sdk.TypedEventToEvent(&authz.EventRevoke{MsgTypeUrl: "/cosmos.bank.v1beta1.MsgSend", Granter: "a", Grantee: "c"})
+	require.NoError(t, err)
+
+	evts := []abci.Event{event("message", "action", "grant"), abci.Event(grant), abci.Event(revoke)}
+
+	grants, err := ParseTyped[*authz.EventGrant](evts)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	require.Equal(t, "b", grants[0].Grantee)
+
+	revoked, err := FirstTyped(evts, func(e *authz.EventRevoke) bool { return e.Grantee == "c" })
+	require.NoError(t, err)
+	require.Equal(t, "a", revoked.Granter)
+
+	_, err = FirstTyped(evts, func(e *authz.EventRevoke) bool { return e.Grantee == "b" })
+	require.Error(t, err)
+}
+
+func TestTxError(t *testing.T) {
+	require.NoError(t, TxError(sdk.TxResponse{}))
+
+	resp := sdk.TxResponse{
+		TxHash:    "ABC",
+		Codespace: sdkerrors.ErrInsufficientFunds.Codespace(),
+		Code:      sdkerrors.ErrInsufficientFunds.ABCICode(),
+		RawLog:    "spendable balance 0utia is smaller than 1utia",
+	}
+	err := TxError(resp)
+	require.True(t, errors.Is(err, sdkerrors.ErrInsufficientFunds))
+	require.ErrorContains(t, err, resp.RawLog)
+
+	require.True(t, IsTxError(resp, sdkerrors.ErrInsufficientFunds))
+	require.NoError(t, CheckTxCode(resp, sdkerrors.ErrInsufficientFunds))
+	require.Error(t, CheckTxCode(resp, sdkerrors.ErrOutOfGas))
+	require.Error(t, CheckTxCode(sdk.TxResponse{}, sdkerrors.ErrOutOfGas))
+
+	// errors of unknown codespaces are still reported.
+	require.ErrorContains(t, TxError(sdk.TxResponse{Codespace: "unknown", Code: 42, RawLog: "boom"}), "boom")
+}
+
+func TestTxQuery(t *testing.T) {
+	query, err := TxQuery("transfer", Attr("recipient", "a"))
+	require.NoError(t, err)
+	require.Equal(t, "tm.event='Tx' AND transfer.recipient='a'", query)
+
+	query, err = TxQuery("transfer", Attr("recipient", "a"), Attr("amount", "1utia"))
+	require.NoError(t, err)
+	require.Equal(t, "tm.event='Tx' AND transfer.recipient='a' AND transfer.amount='1utia'", query)
+
+	_, err = TxQuery("message", Attr("memo", "it's a test"))
+	require.Error(t, err, "values containing single quotes cannot be queried")
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// subscriberSeq makes the subscriber names unique, CometBFT rejects a second subscription of a subscriber to
+// the same query.
+var subscriberSeq atomic.Uint64
+
+// TxQuery returns the CometBFT subscription query matching transactions which emitted an event of the given type
+// with all the given attributes, e.g. TxQuery("transfer", Attr("recipient", addr)). Without attributes the query
+// matches every transaction, as CometBFT can only match events by their attributes. CometBFT queries cannot
+// escape the single quotes delimiting values, so an error is returned for values containing one.
+func TxQuery(eventType string, attrs ...Attribute) (string, error) {
+	conditions := []string{fmt.Sprintf("%s='%s'", cmttypes.EventTypeKey, cmttypes.EventTx)}
+	for _, attr := range attrs {
+		if strings.Contains(attr.Value, "'") {
+			return "", fmt.Errorf("value of attribute %s contains a single quote: %q", attr.Key, attr.Value)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s.%s='%s'", eventType, attr.Key, attr.Value))
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// WaitForTx subscribes to the query, see TxQuery, and returns the first transaction matching it which is
+// committed after the call. The client has to be started, e.g. with (*rpchttp.HTTP).Start, for subscriptions
+// to be available.
+func WaitForTx(ctx context.Context, client rpcclient.EventsClient, query string) (cmttypes.EventDataTx, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	subscriber := fmt.Sprintf("tastora-events-%d", subscriberSeq.Add(1))
+	results, err := client.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		return cmttypes.EventDataTx{}, fmt.Errorf("failed to subscribe to %q: %w", query, err)
+	}
+	defer func() {
+		// the context of the caller may be done already.
+		_ = client.Unsubscribe(context.Background(), subscriber, query)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return cmttypes.EventDataTx{}, fmt.Errorf("timed out waiting for tx matching %q: %w", query, ctx.Err())
+		case res, ok := <-results:
+			if !ok {
+				return cmttypes.EventDataTx{}, fmt.Errorf("subscription to %q closed", query)
+			}
+			if tx, ok := res.Data.(cmttypes.EventDataTx); ok {
+				return tx, nil
+			}
+		}
+	}
+}
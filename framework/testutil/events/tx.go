@@ -0,0 +1,54 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"github.com/celestiaorg/tastora/framework/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TxError returns nil if the transaction succeeded, and otherwise the error registered for its codespace and
+// code wrapping its log, so that it can be matched with errors.Is, e.g. against sdkerrors.ErrInsufficientFunds.
+// Errors of modules which are not linked into the binary are returned unregistered with their codespace and code.
+func TxError(resp sdk.TxResponse) error {
+	if resp.Code == 0 {
+		return nil
+	}
+	return errorsmod.ABCIError(resp.Codespace, resp.Code, resp.RawLog)
+}
+
+// CheckTxCode returns an error unless the transaction failed with the given registered error.
+func CheckTxCode(resp sdk.TxResponse, expected *errorsmod.Error) error {
+	if resp.Code == 0 {
+		return fmt.Errorf("tx %s succeeded, expected %s (codespace %s, code %d)", resp.TxHash, expected, expected.Codespace(), expected.ABCICode())
+	}
+	if resp.Codespace != expected.Codespace() || resp.Code != expected.ABCICode() {
+		return fmt.Errorf("tx %s failed with %w, expected %s (codespace %s, code %d)", resp.TxHash, TxError(resp), expected, expected.Codespace(), expected.ABCICode())
+	}
+	return nil
+}
+
+// RequireTxSuccess fails the test if the transaction did not succeed, reporting the decoded error.
+func RequireTxSuccess(t types.TestingT, resp sdk.TxResponse) {
+	t.Helper()
+	if err := TxError(resp); err != nil {
+		t.Errorf("tx %s failed (codespace %s, code %d): %v", resp.TxHash, resp.Codespace, resp.Code, err)
+		t.FailNow()
+	}
+}
+
+// RequireTxCode fails the test unless the transaction failed with the given registered error.
+func RequireTxCode(t types.TestingT, resp sdk.TxResponse, expected *errorsmod.Error) {
+	t.Helper()
+	if err := CheckTxCode(resp, expected); err != nil {
+		t.Errorf("%v", err)
+		t.FailNow()
+	}
+}
+
+// IsTxError returns true if the transaction failed with the given registered error.
+func IsTxError(resp sdk.TxResponse, target *errorsmod.Error) bool {
+	return errors.Is(TxError(resp), target)
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// ParseTyped decodes the typed events of type T, e.g. *banktypes.EventSend, emitted with EmitTypedEvent.
+// The type has to be registered with the proto registry, which is the case for generated types.
+func ParseTyped[T proto.Message](events []abci.Event) ([]T, error) {
+	var zero T
+	eventType := proto.MessageName(zero)
+	if eventType == "" {
+		return nil, fmt.Errorf("%T is not a registered proto message", zero)
+	}
+
+	var typed []T
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		msg, err := sdk.ParseTypedEvent(e)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse event %s: %w", e.Type, err)
+		}
+		t, ok := msg.(T)
+		if !ok {
+			return nil, fmt.Errorf("event %s decoded as %T, expected %T", e.Type, msg, zero)
+		}
+		typed = append(typed, t)
+	}
+	return typed, nil
+}
+
+// FirstTyped decodes the first typed event of type T which satisfies match, all events if match is nil.
+func FirstTyped[T proto.Message](events []abci.Event, match func(T) bool) (T, error) {
+	var zero T
+	typed, err := ParseTyped[T](events)
+	if err != nil {
+		return zero, err
+	}
+	for _, t := range typed {
+		if match == nil || match(t) {
+			return t, nil
+		}
+	}
+	return zero, fmt.Errorf("event %s not found", proto.MessageName(zero))
+}
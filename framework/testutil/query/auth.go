@@ -0,0 +1,35 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// Account queries the account of an address. The account is unpacked with the unpacker, typically the
+// interface registry of the chain's encoding config, which must have the auth types registered.
+func Account(ctx context.Context, grpcConn grpc.ClientConn, unpacker codectypes.AnyUnpacker, address string) (sdk.AccountI, error) {
+	res, err := authtypes.NewQueryClient(grpcConn).Account(ctx, &authtypes.QueryAccountRequest{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account %s: %w", address, err)
+	}
+
+	var account sdk.AccountI
+	if err := unpacker.UnpackAny(res.Account, &account); err != nil {
+		return nil, fmt.Errorf("failed to unpack account %s: %w", address, err)
+	}
+	return account, nil
+}
+
+// AuthParams queries the parameters of the auth module.
+func AuthParams(ctx context.Context, grpcConn grpc.ClientConn) (authtypes.Params, error) {
+	res, err := authtypes.NewQueryClient(grpcConn).Params(ctx, &authtypes.QueryParamsRequest{})
+	if err != nil {
+		return authtypes.Params{}, fmt.Errorf("failed to query auth params: %w", err)
+	}
+	return res.Params, nil
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// AllBalances queries the balances of an address in all denoms.
+func AllBalances(ctx context.Context, grpcConn grpc.ClientConn, address string) (sdk.Coins, error) {
+	client := banktypes.NewQueryClient(grpcConn)
+	balances, err := allPages(func(page *sdkquery.PageRequest) ([]sdk.Coin, *sdkquery.PageResponse, error) {
+		res, err := client.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: address, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Balances, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balances for %s: %w", address, err)
+	}
+	return sdk.NewCoins(balances...), nil
+}
+
+// TotalSupply queries the total supply of all denoms.
+func TotalSupply(ctx context.Context, grpcConn grpc.ClientConn) (sdk.Coins, error) {
+	client := banktypes.NewQueryClient(grpcConn)
+	supply, err := allPages(func(page *sdkquery.PageRequest) ([]sdk.Coin, *sdkquery.PageResponse, error) {
+		res, err := client.TotalSupply(ctx, &banktypes.QueryTotalSupplyRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Supply, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query total supply: %w", err)
+	}
+	return sdk.NewCoins(supply...), nil
+}
+
+// SupplyOf queries the total supply of a denom.
+func SupplyOf(ctx context.Context, grpcConn grpc.ClientConn, denom string) (sdk.Coin, error) {
+	res, err := banktypes.NewQueryClient(grpcConn).SupplyOf(ctx, &banktypes.QuerySupplyOfRequest{Denom: denom})
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("failed to query supply of %s: %w", denom, err)
+	}
+	return res.Amount, nil
+}
@@ -0,0 +1,111 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// blobParamsMethod is the gRPC method of celestia-app's blob module which returns its parameters.
+const blobParamsMethod = "/celestia.blob.v1.Query/Params"
+
+// BlobParams are the parameters of celestia-app's blob module.
+type BlobParams struct {
+	// GasPerBlobByte is the gas consumed per byte of blob data.
+	GasPerBlobByte uint32
+	// GovMaxSquareSize is the maximum size of the data square set by governance.
+	GovMaxSquareSize uint64
+}
+
+// BlobModuleParams queries the parameters of celestia-app's blob module.
+func BlobModuleParams(ctx context.Context, grpcConn grpc.ClientConn) (BlobParams, error) {
+	var res blobParamsResponse
+	if err := grpcConn.Invoke(ctx, blobParamsMethod, &blobParamsRequest{}, &res); err != nil {
+		return BlobParams{}, fmt.Errorf("failed to query blob params: %w", err)
+	}
+	return res.params, nil
+}
+
+// blobParamsRequest is the celestia.blob.v1.QueryParamsRequest message. The messages of celestia-app are
+// encoded by hand so that celestia-app does not have to be a dependency.
+type blobParamsRequest struct{}
+
+func (*blobParamsRequest) Reset()                   {}
+func (*blobParamsRequest) String() string           { return "QueryParamsRequest{}" }
+func (*blobParamsRequest) ProtoMessage()            {}
+func (*blobParamsRequest) Marshal() ([]byte, error) { return nil, nil }
+func (*blobParamsRequest) Unmarshal([]byte) error   { return nil }
+
+// blobParamsResponse is the celestia.blob.v1.QueryParamsResponse message.
+type blobParamsResponse struct {
+	params BlobParams
+}
+
+func (m *blobParamsResponse) Reset() { *m = blobParamsResponse{} }
+func (m *blobParamsResponse) String() string {
+	return fmt.Sprintf("QueryParamsResponse{%+v}", m.params)
+}
+func (*blobParamsResponse) ProtoMessage() {}
+
+func (m *blobParamsResponse) Marshal() ([]byte, error) {
+	var params []byte
+	if m.params.GasPerBlobByte != 0 {
+		params = protowire.AppendTag(params, 1, protowire.VarintType)
+		params = protowire.AppendVarint(params, uint64(m.params.GasPerBlobByte))
+	}
+	if m.params.GovMaxSquareSize != 0 {
+		params = protowire.AppendTag(params, 2, protowire.VarintType)
+		params = protowire.AppendVarint(params, m.params.GovMaxSquareSize)
+	}
+	b := protowire.AppendTag(nil, 1, protowire.BytesType)
+	return protowire.AppendBytes(b, params), nil
+}
+
+func (m *blobParamsResponse) Unmarshal(b []byte) error {
+	*m = blobParamsResponse{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 || typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		params, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		return n, consumeFields(params, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+			if typ != protowire.VarintType || (num != 1 && num != 2) {
+				return protowire.ConsumeFieldValue(num, typ, b), nil
+			}
+			v, n := protowire.ConsumeVarint(b)
+			if num == 1 {
+				m.params.GasPerBlobByte = uint32(v)
+			} else {
+				m.params.GovMaxSquareSize = v
+			}
+			return n, nil
+		})
+	})
+}
+
+// consumeFields calls consume for every field of the encoded message with the bytes following the field's tag.
+// consume returns the length of the field's value, negative if it is malformed.
+func consumeFields(b []byte, consume func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		n, err := consume(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
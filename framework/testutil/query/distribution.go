@@ -0,0 +1,44 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// DelegationRewards queries the rewards accrued by the delegation of a delegator to a validator.
+func DelegationRewards(ctx context.Context, grpcConn grpc.ClientConn, delegatorAddr, valAddr string) (sdk.DecCoins, error) {
+	res, err := distrtypes.NewQueryClient(grpcConn).DelegationRewards(ctx, &distrtypes.QueryDelegationRewardsRequest{
+		DelegatorAddress: delegatorAddr,
+		ValidatorAddress: valAddr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rewards of %s from %s: %w", delegatorAddr, valAddr, err)
+	}
+	return res.Rewards, nil
+}
+
+// DelegationTotalRewards queries the rewards accrued by all delegations of a delegator, per validator and in total.
+func DelegationTotalRewards(ctx context.Context, grpcConn grpc.ClientConn, delegatorAddr string) ([]distrtypes.DelegationDelegatorReward, sdk.DecCoins, error) {
+	res, err := distrtypes.NewQueryClient(grpcConn).DelegationTotalRewards(ctx, &distrtypes.QueryDelegationTotalRewardsRequest{
+		DelegatorAddress: delegatorAddr,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query rewards of %s: %w", delegatorAddr, err)
+	}
+	return res.Rewards, res.Total, nil
+}
+
+// ValidatorCommission queries the commission accrued by a validator.
+func ValidatorCommission(ctx context.Context, grpcConn grpc.ClientConn, valAddr string) (sdk.DecCoins, error) {
+	res, err := distrtypes.NewQueryClient(grpcConn).ValidatorCommission(ctx, &distrtypes.QueryValidatorCommissionRequest{
+		ValidatorAddress: valAddr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commission of %s: %w", valAddr, err)
+	}
+	return res.Commission.Commission, nil
+}
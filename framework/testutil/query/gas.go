@@ -0,0 +1,115 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	nodeservice "github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// estimateGasPriceMethod is the gRPC method of celestia-app's gas estimation service which estimates the gas
+// price of a transaction from the gas prices of the transactions in the mempool.
+const estimateGasPriceMethod = "/celestia.core.v1.gas_estimation.GasEstimator/EstimateGasPrice"
+
+// TxPriority is the priority a gas price is estimated for by EstimateGasPrice.
+type TxPriority int32
+
+const (
+	// TxPriorityLow estimates a gas price below most transactions in the mempool.
+	TxPriorityLow TxPriority = 1
+	// TxPriorityMedium estimates the median gas price of the transactions in the mempool.
+	TxPriorityMedium TxPriority = 2
+	// TxPriorityHigh estimates a gas price above most transactions in the mempool.
+	TxPriorityHigh TxPriority = 3
+)
+
+// MinGasPrices queries the minimum gas prices the node accepts transactions with, which are empty if the node
+// accepts transactions without fees.
+func MinGasPrices(ctx context.Context, grpcConn grpc.ClientConn) (sdk.DecCoins, error) {
+	res, err := nodeservice.NewServiceClient(grpcConn).Config(ctx, &nodeservice.ConfigRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node config: %w", err)
+	}
+
+	prices, err := sdk.ParseDecCoins(res.MinimumGasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse minimum gas price %q: %w", res.MinimumGasPrice, err)
+	}
+	return prices, nil
+}
+
+// EstimateGasPrice queries celestia-app's gas estimation service for the gas price, in the chain's native
+// denom, a transaction of the given priority should pay. The estimate is never below the network minimum gas price.
+func EstimateGasPrice(ctx context.Context, grpcConn grpc.ClientConn, priority TxPriority) (float64, error) {
+	var res estimateGasPriceResponse
+	if err := grpcConn.Invoke(ctx, estimateGasPriceMethod, &estimateGasPriceRequest{priority: priority}, &res); err != nil {
+		return 0, fmt.Errorf("failed to estimate gas price: %w", err)
+	}
+	return res.price, nil
+}
+
+// estimateGasPriceRequest is the celestia.core.v1.gas_estimation.EstimateGasPriceRequest message.
+type estimateGasPriceRequest struct {
+	priority TxPriority
+}
+
+func (m *estimateGasPriceRequest) Reset() { *m = estimateGasPriceRequest{} }
+func (m *estimateGasPriceRequest) String() string {
+	return fmt.Sprintf("EstimateGasPriceRequest{priority: %d}", m.priority)
+}
+func (*estimateGasPriceRequest) ProtoMessage() {}
+
+func (m *estimateGasPriceRequest) Marshal() ([]byte, error) {
+	if m.priority == 0 {
+		return nil, nil
+	}
+	b := protowire.AppendTag(nil, 1, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(m.priority)), nil
+}
+
+func (m *estimateGasPriceRequest) Unmarshal(b []byte) error {
+	*m = estimateGasPriceRequest{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 || typ != protowire.VarintType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeVarint(b)
+		m.priority = TxPriority(v)
+		return n, nil
+	})
+}
+
+// estimateGasPriceResponse is the celestia.core.v1.gas_estimation.EstimateGasPriceResponse message.
+type estimateGasPriceResponse struct {
+	price float64
+}
+
+func (m *estimateGasPriceResponse) Reset() { *m = estimateGasPriceResponse{} }
+func (m *estimateGasPriceResponse) String() string {
+	return fmt.Sprintf("EstimateGasPriceResponse{price: %v}", m.price)
+}
+func (*estimateGasPriceResponse) ProtoMessage() {}
+
+func (m *estimateGasPriceResponse) Marshal() ([]byte, error) {
+	if m.price == 0 {
+		return nil, nil
+	}
+	b := protowire.AppendTag(nil, 1, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(m.price)), nil
+}
+
+func (m *estimateGasPriceResponse) Unmarshal(b []byte) error {
+	*m = estimateGasPriceResponse{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 || typ != protowire.Fixed64Type {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeFixed64(b)
+		m.price = math.Float64frombits(v)
+		return n, nil
+	})
+}
@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// Proposals queries the governance proposals with the given status, or all proposals if the status is
+// govv1.StatusNil.
+func Proposals(ctx context.Context, grpcConn grpc.ClientConn, status govv1.ProposalStatus) ([]*govv1.Proposal, error) {
+	client := govv1.NewQueryClient(grpcConn)
+	proposals, err := allPages(func(page *sdkquery.PageRequest) ([]*govv1.Proposal, *sdkquery.PageResponse, error) {
+		res, err := client.Proposals(ctx, &govv1.QueryProposalsRequest{ProposalStatus: status, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Proposals, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query proposals: %w", err)
+	}
+	return proposals, nil
+}
+
+// Proposal queries the governance proposal with the given ID.
+func Proposal(ctx context.Context, grpcConn grpc.ClientConn, proposalID uint64) (*govv1.Proposal, error) {
+	res, err := govv1.NewQueryClient(grpcConn).Proposal(ctx, &govv1.QueryProposalRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query proposal %d: %w", proposalID, err)
+	}
+	return res.Proposal, nil
+}
+
+// Votes queries the votes cast on a governance proposal. Votes are removed once the voting period has ended.
+func Votes(ctx context.Context, grpcConn grpc.ClientConn, proposalID uint64) ([]*govv1.Vote, error) {
+	client := govv1.NewQueryClient(grpcConn)
+	votes, err := allPages(func(page *sdkquery.PageRequest) ([]*govv1.Vote, *sdkquery.PageResponse, error) {
+		res, err := client.Votes(ctx, &govv1.QueryVotesRequest{ProposalId: proposalID, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Votes, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query votes of proposal %d: %w", proposalID, err)
+	}
+	return votes, nil
+}
+
+// Tally queries the current tally of a governance proposal, or the final tally once the voting period has ended.
+func Tally(ctx context.Context, grpcConn grpc.ClientConn, proposalID uint64) (*govv1.TallyResult, error) {
+	res, err := govv1.NewQueryClient(grpcConn).TallyResult(ctx, &govv1.QueryTallyResultRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tally of proposal %d: %w", proposalID, err)
+	}
+	return res.Tally, nil
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/gogoproto/grpc"
+	"github.com/cosmos/gogoproto/proto"
+	icacontrollertypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+)
+
+// ClientStates queries the states of all IBC light clients.
+func ClientStates(ctx context.Context, grpcConn grpc.ClientConn) (clienttypes.IdentifiedClientStates, error) {
+	client := clienttypes.NewQueryClient(grpcConn)
+	states, err := allPages(func(page *sdkquery.PageRequest) ([]clienttypes.IdentifiedClientState, *sdkquery.PageResponse, error) {
+		res, err := client.ClientStates(ctx, &clienttypes.QueryClientStatesRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.ClientStates, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client states: %w", err)
+	}
+	return states, nil
+}
+
+// ClientStatus queries the status of an IBC light client, e.g. ibcexported.Active or ibcexported.Expired.
+func ClientStatus(ctx context.Context, grpcConn grpc.ClientConn, clientID string) (ibcexported.Status, error) {
+	res, err := clienttypes.NewQueryClient(grpcConn).ClientStatus(ctx, &clienttypes.QueryClientStatusRequest{ClientId: clientID})
+	if err != nil {
+		return "", fmt.Errorf("failed to query status of client %s: %w", clientID, err)
+	}
+	return ibcexported.Status(res.Status), nil
+}
+
+// TendermintClientState queries the state of an IBC tendermint light client.
+func TendermintClientState(ctx context.Context, grpcConn grpc.ClientConn, clientID string) (*ibctm.ClientState, error) {
+	res, err := clienttypes.NewQueryClient(grpcConn).ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query state of client %s: %w", clientID, err)
+	}
+	var state ibctm.ClientState
+	if err := proto.Unmarshal(res.ClientState.GetValue(), &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state of client %s: %w", clientID, err)
+	}
+	return &state, nil
+}
+
+// Connections queries all IBC connections.
+func Connections(ctx context.Context, grpcConn grpc.ClientConn) ([]*connectiontypes.IdentifiedConnection, error) {
+	client := connectiontypes.NewQueryClient(grpcConn)
+	connections, err := allPages(func(page *sdkquery.PageRequest) ([]*connectiontypes.IdentifiedConnection, *sdkquery.PageResponse, error) {
+		res, err := client.Connections(ctx, &connectiontypes.QueryConnectionsRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Connections, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections: %w", err)
+	}
+	return connections, nil
+}
+
+// Connection queries the IBC connection with the given ID.
+func Connection(ctx context.Context, grpcConn grpc.ClientConn, connectionID string) (*connectiontypes.ConnectionEnd, error) {
+	res, err := connectiontypes.NewQueryClient(grpcConn).Connection(ctx, &connectiontypes.QueryConnectionRequest{ConnectionId: connectionID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connection %s: %w", connectionID, err)
+	}
+	return res.Connection, nil
+}
+
+// Channels queries all IBC channels.
+func Channels(ctx context.Context, grpcConn grpc.ClientConn) ([]*channeltypes.IdentifiedChannel, error) {
+	client := channeltypes.NewQueryClient(grpcConn)
+	channels, err := allPages(func(page *sdkquery.PageRequest) ([]*channeltypes.IdentifiedChannel, *sdkquery.PageResponse, error) {
+		res, err := client.Channels(ctx, &channeltypes.QueryChannelsRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Channels, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query channels: %w", err)
+	}
+	return channels, nil
+}
+
+// Channel queries the IBC channel with the given port and channel ID.
+func Channel(ctx context.Context, grpcConn grpc.ClientConn, portID, channelID string) (*channeltypes.Channel, error) {
+	res, err := channeltypes.NewQueryClient(grpcConn).Channel(ctx, &channeltypes.QueryChannelRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query channel %s/%s: %w", portID, channelID, err)
+	}
+	return res.Channel, nil
+}
+
+// PacketCommitments queries the commitments of the packets sent on a channel which have not been acknowledged
+// or timed out yet.
+func PacketCommitments(ctx context.Context, grpcConn grpc.ClientConn, portID, channelID string) ([]*channeltypes.PacketState, error) {
+	client := channeltypes.NewQueryClient(grpcConn)
+	commitments, err := allPages(func(page *sdkquery.PageRequest) ([]*channeltypes.PacketState, *sdkquery.PageResponse, error) {
+		res, err := client.PacketCommitments(ctx, &channeltypes.QueryPacketCommitmentsRequest{PortId: portID, ChannelId: channelID, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Commitments, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query packet commitments of %s/%s: %w", portID, channelID, err)
+	}
+	return commitments, nil
+}
+
+// PacketAcknowledgements queries the acknowledgements written for the packets received on a channel.
+func PacketAcknowledgements(ctx context.Context, grpcConn grpc.ClientConn, portID, channelID string) ([]*channeltypes.PacketState, error) {
+	client := channeltypes.NewQueryClient(grpcConn)
+	acks, err := allPages(func(page *sdkquery.PageRequest) ([]*channeltypes.PacketState, *sdkquery.PageResponse, error) {
+		res, err := client.PacketAcknowledgements(ctx, &channeltypes.QueryPacketAcknowledgementsRequest{PortId: portID, ChannelId: channelID, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Acknowledgements, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query packet acknowledgements of %s/%s: %w", portID, channelID, err)
+	}
+	return acks, nil
+}
+
+// UnreceivedPackets returns the sequences, out of the given packet commitment sequences of the counterparty, of
+// the packets which have not been received on the channel.
+func UnreceivedPackets(ctx context.Context, grpcConn grpc.ClientConn, portID, channelID string, sequences []uint64) ([]uint64, error) {
+	res, err := channeltypes.NewQueryClient(grpcConn).UnreceivedPackets(ctx, &channeltypes.QueryUnreceivedPacketsRequest{
+		PortId:                    portID,
+		ChannelId:                 channelID,
+		PacketCommitmentSequences: sequences,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unreceived packets of %s/%s: %w", portID, channelID, err)
+	}
+	return res.Sequences, nil
+}
+
+// UnreceivedAcks returns the sequences, out of the given acknowledgement sequences of the counterparty, of the
+// packets sent on the channel whose acknowledgements have not been received.
+func UnreceivedAcks(ctx context.Context, grpcConn grpc.ClientConn, portID, channelID string, sequences []uint64) ([]uint64, error) {
+	res, err := channeltypes.NewQueryClient(grpcConn).UnreceivedAcks(ctx, &channeltypes.QueryUnreceivedAcksRequest{
+		PortId:             portID,
+		ChannelId:          channelID,
+		PacketAckSequences: sequences,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unreceived acks of %s/%s: %w", portID, channelID, err)
+	}
+	return res.Sequences, nil
+}
+
+// InterchainAccount queries the address on the host chain of the interchain account registered by the owner on
+// the connection of the controller chain.
+func InterchainAccount(ctx context.Context, grpcConn grpc.ClientConn, owner, connectionID string) (string, error) {
+	res, err := icacontrollertypes.NewQueryClient(grpcConn).InterchainAccount(ctx, &icacontrollertypes.QueryInterchainAccountRequest{
+		Owner:        owner,
+		ConnectionId: connectionID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to query interchain account of %s on %s: %w", owner, connectionID, err)
+	}
+	return res.Address, nil
+}
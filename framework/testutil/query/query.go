@@ -1,13 +1,26 @@
+// Package query provides helpers to query the state of cosmos chains over gRPC.
+// Queries are made at the latest height unless the context is pinned to a height with AtHeight.
 package query
 
 import (
 	"context"
-	sdkmath "cosmossdk.io/math"
 	"fmt"
+	"strconv"
+
+	sdkmath "cosmossdk.io/math"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+// AtHeight returns a context which pins the queries made with it to the state at the given height.
+// The node has to retain the state of the height, which is not the case for pruned heights.
+func AtHeight(ctx context.Context, height int64) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+}
+
 // Balance queries the balance of an address for a specific denom.
 func Balance(ctx context.Context, grpcConn grpc.ClientConn, address string, denom string) (sdkmath.Int, error) {
 	bankClient := banktypes.NewQueryClient(grpcConn)
@@ -28,3 +41,23 @@ func Balance(ctx context.Context, grpcConn grpc.ClientConn, address string, deno
 
 	return resp.Balance.Amount, nil
 }
+
+// allPages calls fetch with the key of the next page until all pages have been fetched and returns the
+// concatenated results.
+func allPages[T any](fetch func(page *sdkquery.PageRequest) ([]T, *sdkquery.PageResponse, error)) ([]T, error) {
+	var (
+		all     []T
+		nextKey []byte
+	)
+	for {
+		items, res, err := fetch(&sdkquery.PageRequest{Key: nextKey})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if res == nil || len(res.NextKey) == 0 {
+			return all, nil
+		}
+		nextKey = res.NextKey
+	}
+}
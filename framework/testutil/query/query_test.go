@@ -0,0 +1,72 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestAtHeight(t *testing.T) {
+	md, ok := metadata.FromOutgoingContext(AtHeight(context.Background(), 42))
+	require.True(t, ok)
+	require.Equal(t, []string{"42"}, md.Get("x-cosmos-block-height"))
+}
+
+func TestAllPages(t *testing.T) {
+	pages := map[string][]int{"": {1, 2}, "a": {3, 4}, "b": {5}}
+	next := map[string]string{"": "a", "a": "b", "b": ""}
+
+	items, err := allPages(func(page *sdkquery.PageRequest) ([]int, *sdkquery.PageResponse, error) {
+		key := string(page.Key)
+		return pages[key], &sdkquery.PageResponse{NextKey: []byte(next[key])}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, items)
+
+	_, err = allPages(func(*sdkquery.PageRequest) ([]int, *sdkquery.PageResponse, error) {
+		return nil, nil, errors.New("boom")
+	})
+	require.Error(t, err)
+}
+
+func TestBlobParamsResponse(t *testing.T) {
+	want := blobParamsResponse{params: BlobParams{GasPerBlobByte: 8, GovMaxSquareSize: 128}}
+	bz, err := want.Marshal()
+	require.NoError(t, err)
+
+	// unknown fields are skipped.
+	bz = protowire.AppendTag(bz, 7, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, []byte("ignored"))
+
+	var got blobParamsResponse
+	require.NoError(t, got.Unmarshal(bz))
+	require.Equal(t, want, got)
+
+	require.Error(t, got.Unmarshal([]byte{0x0a, 0x05, 0x08}), "truncated message should fail")
+}
+
+func TestEstimateGasPriceMessages(t *testing.T) {
+	req := estimateGasPriceRequest{priority: TxPriorityHigh}
+	bz, err := req.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x08, 0x03}, bz)
+
+	var gotReq estimateGasPriceRequest
+	require.NoError(t, gotReq.Unmarshal(bz))
+	require.Equal(t, req, gotReq)
+
+	res := estimateGasPriceResponse{price: 0.0045}
+	bz, err = res.Marshal()
+	require.NoError(t, err)
+
+	var gotRes estimateGasPriceResponse
+	require.NoError(t, gotRes.Unmarshal(bz))
+	require.Equal(t, res, gotRes)
+
+	require.Error(t, gotRes.Unmarshal([]byte{0x09, 0x01}), "truncated message should fail")
+}
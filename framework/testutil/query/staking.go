@@ -0,0 +1,80 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// Validators queries the validators with the given status, e.g. stakingtypes.BondStatusBonded, or all
+// validators if the status is empty.
+func Validators(ctx context.Context, grpcConn grpc.ClientConn, status string) ([]stakingtypes.Validator, error) {
+	client := stakingtypes.NewQueryClient(grpcConn)
+	validators, err := allPages(func(page *sdkquery.PageRequest) ([]stakingtypes.Validator, *sdkquery.PageResponse, error) {
+		res, err := client.Validators(ctx, &stakingtypes.QueryValidatorsRequest{Status: status, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Validators, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validators: %w", err)
+	}
+	return validators, nil
+}
+
+// Validator queries the validator with the given operator address.
+func Validator(ctx context.Context, grpcConn grpc.ClientConn, valAddr string) (stakingtypes.Validator, error) {
+	res, err := stakingtypes.NewQueryClient(grpcConn).Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valAddr})
+	if err != nil {
+		return stakingtypes.Validator{}, fmt.Errorf("failed to query validator %s: %w", valAddr, err)
+	}
+	return res.Validator, nil
+}
+
+// Delegation queries the delegation of a delegator to a validator.
+func Delegation(ctx context.Context, grpcConn grpc.ClientConn, delegatorAddr, valAddr string) (stakingtypes.DelegationResponse, error) {
+	res, err := stakingtypes.NewQueryClient(grpcConn).Delegation(ctx, &stakingtypes.QueryDelegationRequest{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: valAddr,
+	})
+	if err != nil {
+		return stakingtypes.DelegationResponse{}, fmt.Errorf("failed to query delegation of %s to %s: %w", delegatorAddr, valAddr, err)
+	}
+	return *res.DelegationResponse, nil
+}
+
+// DelegatorDelegations queries all delegations of a delegator.
+func DelegatorDelegations(ctx context.Context, grpcConn grpc.ClientConn, delegatorAddr string) (stakingtypes.DelegationResponses, error) {
+	client := stakingtypes.NewQueryClient(grpcConn)
+	delegations, err := allPages(func(page *sdkquery.PageRequest) ([]stakingtypes.DelegationResponse, *sdkquery.PageResponse, error) {
+		res, err := client.DelegatorDelegations(ctx, &stakingtypes.QueryDelegatorDelegationsRequest{DelegatorAddr: delegatorAddr, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.DelegationResponses, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delegations of %s: %w", delegatorAddr, err)
+	}
+	return delegations, nil
+}
+
+// ValidatorDelegations queries all delegations to a validator.
+func ValidatorDelegations(ctx context.Context, grpcConn grpc.ClientConn, valAddr string) (stakingtypes.DelegationResponses, error) {
+	client := stakingtypes.NewQueryClient(grpcConn)
+	delegations, err := allPages(func(page *sdkquery.PageRequest) ([]stakingtypes.DelegationResponse, *sdkquery.PageResponse, error) {
+		res, err := client.ValidatorDelegations(ctx, &stakingtypes.QueryValidatorDelegationsRequest{ValidatorAddr: valAddr, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.DelegationResponses, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delegations to %s: %w", valAddr, err)
+	}
+	return delegations, nil
+}
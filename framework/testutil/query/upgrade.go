@@ -0,0 +1,27 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	"github.com/cosmos/gogoproto/grpc"
+)
+
+// CurrentPlan queries the currently scheduled upgrade plan, nil if no upgrade is scheduled.
+func CurrentPlan(ctx context.Context, grpcConn grpc.ClientConn) (*upgradetypes.Plan, error) {
+	res, err := upgradetypes.NewQueryClient(grpcConn).CurrentPlan(ctx, &upgradetypes.QueryCurrentPlanRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query current upgrade plan: %w", err)
+	}
+	return res.Plan, nil
+}
+
+// AppliedPlan queries the height at which the upgrade with the given name was applied, 0 if it has not been applied.
+func AppliedPlan(ctx context.Context, grpcConn grpc.ClientConn, name string) (int64, error) {
+	res, err := upgradetypes.NewQueryClient(grpcConn).AppliedPlan(ctx, &upgradetypes.QueryAppliedPlanRequest{Name: name})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query applied upgrade plan %s: %w", name, err)
+	}
+	return res.Height, nil
+}
@@ -0,0 +1,334 @@
+package topology
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/celestiaorg/tastora/framework/docker/container"
+	"github.com/celestiaorg/tastora/framework/docker/ibc"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// RelayerTypeHermes is the Hermes IBC relayer, the default.
+	RelayerTypeHermes = "hermes"
+	// RelayerTypeGoRelayer is the cosmos Go relayer (rly).
+	RelayerTypeGoRelayer = "rly"
+)
+
+// nameRE matches names which can be used as part of docker container and host names.
+var nameRE = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,28}[a-z0-9])?$`)
+
+// Spec describes a complete test environment. Components reference each other by name and are started
+// in dependency order: chains, DA networks, reth nodes, evm-single chains, relayers and finally hyperlane.
+type Spec struct {
+	Chains     []ChainSpec     `yaml:"chains" json:"chains"`
+	DANetworks []DANetworkSpec `yaml:"da_networks,omitempty" json:"da_networks,omitempty"`
+	Reth       []RethSpec      `yaml:"reth,omitempty" json:"reth,omitempty"`
+	EVMChains  []EVMChainSpec  `yaml:"evm_chains,omitempty" json:"evm_chains,omitempty"`
+	Relayers   []RelayerSpec   `yaml:"relayers,omitempty" json:"relayers,omitempty"`
+	Hyperlane  *HyperlaneSpec  `yaml:"hyperlane,omitempty" json:"hyperlane,omitempty"`
+}
+
+// ImageSpec describes a docker image.
+type ImageSpec struct {
+	Repository string `yaml:"repository" json:"repository"`
+	Version    string `yaml:"version" json:"version"`
+	UIDGID     string `yaml:"uid_gid,omitempty" json:"uid_gid,omitempty"`
+}
+
+// Image converts the spec into a container.Image.
+func (s ImageSpec) Image() container.Image {
+	return container.NewImage(s.Repository, s.Version, s.UIDGID)
+}
+
+// isSet reports whether an image was specified.
+func (s *ImageSpec) isSet() bool {
+	return s != nil && s.Repository != ""
+}
+
+// ChainSpec describes a cosmos-sdk chain.
+type ChainSpec struct {
+	// Name identifies the chain within the spec and is used as the chain name.
+	Name string `yaml:"name" json:"name"`
+	// ChainID defaults to Name.
+	ChainID string    `yaml:"chain_id,omitempty" json:"chain_id,omitempty"`
+	Image   ImageSpec `yaml:"image" json:"image"`
+	// Binary defaults to celestia-appd.
+	Binary string `yaml:"binary,omitempty" json:"binary,omitempty"`
+	// Bech32Prefix defaults to celestia.
+	Bech32Prefix string `yaml:"bech32_prefix,omitempty" json:"bech32_prefix,omitempty"`
+	// Denom defaults to utia.
+	Denom string `yaml:"denom,omitempty" json:"denom,omitempty"`
+	// GasPrices used when broadcasting transactions, defaults to 0.025<denom>.
+	GasPrices string `yaml:"gas_prices,omitempty" json:"gas_prices,omitempty"`
+	// MinGasPrices configured in app.toml of every node, defaults to 0<denom>.
+	MinGasPrices string `yaml:"min_gas_prices,omitempty" json:"min_gas_prices,omitempty"`
+	// Validators is the number of validators, defaults to 1.
+	Validators int `yaml:"validators,omitempty" json:"validators,omitempty"`
+	// FullNodes is the number of non-validating full nodes.
+	FullNodes int      `yaml:"full_nodes,omitempty" json:"full_nodes,omitempty"`
+	StartArgs []string `yaml:"start_args,omitempty" json:"start_args,omitempty"`
+	Env       []string `yaml:"env,omitempty" json:"env,omitempty"`
+}
+
+// DANetworkSpec describes a celestia-node network backed by one of the chains.
+type DANetworkSpec struct {
+	Name string `yaml:"name" json:"name"`
+	// Chain is the name of the chain the network reads blocks from.
+	Chain string    `yaml:"chain" json:"chain"`
+	Image ImageSpec `yaml:"image" json:"image"`
+	// BridgeNodes is the number of bridge nodes, defaults to 1.
+	BridgeNodes int `yaml:"bridge_nodes,omitempty" json:"bridge_nodes,omitempty"`
+	// LightNodes is the number of light nodes, which connect to the first bridge node.
+	LightNodes int `yaml:"light_nodes,omitempty" json:"light_nodes,omitempty"`
+}
+
+// RethSpec describes a reth execution client.
+type RethSpec struct {
+	// Name identifies the node and is used as its hyperlane chain name.
+	Name  string     `yaml:"name" json:"name"`
+	Image *ImageSpec `yaml:"image,omitempty" json:"image,omitempty"`
+	// ChainID is the EVM chain ID written to the genesis. Defaults to the reth default.
+	ChainID int `yaml:"chain_id,omitempty" json:"chain_id,omitempty"`
+	// DomainID is the hyperlane domain of the chain, defaults to ChainID.
+	DomainID uint32 `yaml:"domain_id,omitempty" json:"domain_id,omitempty"`
+	// GenesisFile is an optional path to a genesis file used instead of the default evolve genesis.
+	GenesisFile string `yaml:"genesis_file,omitempty" json:"genesis_file,omitempty"`
+}
+
+// EVMChainSpec describes an evm-single chain using a reth node for execution and a DA network for data availability.
+type EVMChainSpec struct {
+	Name  string     `yaml:"name" json:"name"`
+	Image *ImageSpec `yaml:"image,omitempty" json:"image,omitempty"`
+	// Reth is the name of the reth node used as the execution client.
+	Reth string `yaml:"reth" json:"reth"`
+	// DANetwork is the name of the DA network blobs are submitted to.
+	DANetwork string `yaml:"da_network" json:"da_network"`
+	// BlockTime defaults to 1s.
+	BlockTime string `yaml:"block_time,omitempty" json:"block_time,omitempty"`
+}
+
+// RelayerSpec describes an IBC relayer connecting two chains.
+type RelayerSpec struct {
+	Name string `yaml:"name" json:"name"`
+	// Type is either hermes or rly, defaults to hermes.
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+	// Chains are the names of the two chains the relayer connects.
+	Chains []string `yaml:"chains" json:"chains"`
+	// Channels are created on a single connection between the chains.
+	// Defaults to an unordered ics20-1 channel between the transfer ports.
+	Channels []ChannelSpec `yaml:"channels,omitempty" json:"channels,omitempty"`
+}
+
+// ChannelSpec describes an IBC channel.
+type ChannelSpec struct {
+	SourcePort string `yaml:"source_port" json:"source_port"`
+	DestPort   string `yaml:"dest_port" json:"dest_port"`
+	// Order is either ordered or unordered, defaults to unordered.
+	Order   string `yaml:"order,omitempty" json:"order,omitempty"`
+	Version string `yaml:"version" json:"version"`
+}
+
+// options converts the spec into ibc.CreateChannelOptions.
+func (s ChannelSpec) options() ibc.CreateChannelOptions {
+	order := ibc.OrderUnordered
+	if s.Order != "" {
+		order = ibc.ChannelOrder(s.Order)
+	}
+	return ibc.CreateChannelOptions{
+		SourcePortName: s.SourcePort,
+		DestPortName:   s.DestPort,
+		Order:          order,
+		Version:        s.Version,
+	}
+}
+
+// HyperlaneSpec describes a hyperlane deployment across chains and reth nodes.
+type HyperlaneSpec struct {
+	// Image of the hyperlane deployer, defaults to hyperlane.DefaultDeployerImage().
+	Image *ImageSpec `yaml:"image,omitempty" json:"image,omitempty"`
+	// Chains are the names of the chains and reth nodes hyperlane is deployed to.
+	Chains []string `yaml:"chains" json:"chains"`
+	// WarpRoutes enroll routers between the collateral token on a cosmos chain and the warp token on a reth node.
+	WarpRoutes []WarpRouteSpec `yaml:"warp_routes,omitempty" json:"warp_routes,omitempty"`
+}
+
+// WarpRouteSpec describes a warp route between a cosmos chain and an EVM chain.
+type WarpRouteSpec struct {
+	Cosmos string `yaml:"cosmos" json:"cosmos"`
+	EVM    string `yaml:"evm" json:"evm"`
+}
+
+// Parse parses a YAML or JSON encoded spec and validates it.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	// JSON is a subset of YAML, so a single decoder handles both formats.
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse topology: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid topology: %w", err)
+	}
+	return &spec, nil
+}
+
+// LoadFile reads and parses the spec at the given path.
+func LoadFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topology file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Validate ensures that every component is named uniquely, that references resolve and that counts are valid.
+func (s *Spec) Validate() error {
+	var errs []error
+
+	if len(s.Chains) == 0 {
+		errs = append(errs, errors.New("at least one chain must be specified"))
+	}
+
+	names := map[string]string{}
+	addName := func(kind, name string) {
+		if !nameRE.MatchString(name) {
+			errs = append(errs, fmt.Errorf("%s name %q must be 1-30 lowercase alphanumeric characters or hyphens", kind, name))
+			return
+		}
+		if existing, ok := names[name]; ok {
+			errs = append(errs, fmt.Errorf("%s name %q is already used by a %s", kind, name, existing))
+			return
+		}
+		names[name] = kind
+	}
+
+	chains := map[string]bool{}
+	chainIDs := map[string]bool{}
+	for _, c := range s.Chains {
+		addName("chain", c.Name)
+		chains[c.Name] = true
+		chainID := c.chainID()
+		if chainIDs[chainID] {
+			errs = append(errs, fmt.Errorf("chain %q: chain id %q is not unique", c.Name, chainID))
+		}
+		chainIDs[chainID] = true
+		if !c.Image.isSet() {
+			errs = append(errs, fmt.Errorf("chain %q: image must be specified", c.Name))
+		}
+		if c.Validators < 0 || c.FullNodes < 0 {
+			errs = append(errs, fmt.Errorf("chain %q: node counts must not be negative", c.Name))
+		}
+	}
+
+	daNetworks := map[string]bool{}
+	for _, d := range s.DANetworks {
+		addName("da network", d.Name)
+		daNetworks[d.Name] = true
+		if !chains[d.Chain] {
+			errs = append(errs, fmt.Errorf("da network %q: unknown chain %q", d.Name, d.Chain))
+		}
+		if !d.Image.isSet() {
+			errs = append(errs, fmt.Errorf("da network %q: image must be specified", d.Name))
+		}
+		if d.BridgeNodes < 0 || d.LightNodes < 0 {
+			errs = append(errs, fmt.Errorf("da network %q: node counts must not be negative", d.Name))
+		}
+	}
+
+	rethNodes := map[string]bool{}
+	for _, r := range s.Reth {
+		addName("reth node", r.Name)
+		rethNodes[r.Name] = true
+		if r.ChainID < 0 {
+			errs = append(errs, fmt.Errorf("reth node %q: chain id must not be negative", r.Name))
+		}
+	}
+
+	usedReth := map[string]bool{}
+	for _, e := range s.EVMChains {
+		addName("evm chain", e.Name)
+		if !rethNodes[e.Reth] {
+			errs = append(errs, fmt.Errorf("evm chain %q: unknown reth node %q", e.Name, e.Reth))
+		} else if usedReth[e.Reth] {
+			errs = append(errs, fmt.Errorf("evm chain %q: reth node %q is already used by another evm chain", e.Name, e.Reth))
+		}
+		usedReth[e.Reth] = true
+		if !daNetworks[e.DANetwork] {
+			errs = append(errs, fmt.Errorf("evm chain %q: unknown da network %q", e.Name, e.DANetwork))
+		}
+	}
+
+	for _, r := range s.Relayers {
+		addName("relayer", r.Name)
+		if r.Type != "" && r.Type != RelayerTypeHermes && r.Type != RelayerTypeGoRelayer {
+			errs = append(errs, fmt.Errorf("relayer %q: unsupported type %q", r.Name, r.Type))
+		}
+		if len(r.Chains) != 2 {
+			errs = append(errs, fmt.Errorf("relayer %q: exactly two chains must be specified", r.Name))
+		} else if r.Chains[0] == r.Chains[1] {
+			errs = append(errs, fmt.Errorf("relayer %q: chains must be distinct", r.Name))
+		}
+		for _, c := range r.Chains {
+			if !chains[c] {
+				errs = append(errs, fmt.Errorf("relayer %q: unknown chain %q", r.Name, c))
+			}
+		}
+		for _, ch := range r.Channels {
+			if ch.SourcePort == "" || ch.DestPort == "" || ch.Version == "" {
+				errs = append(errs, fmt.Errorf("relayer %q: channels require source_port, dest_port and version", r.Name))
+			}
+			if ch.Order != "" && ch.Order != string(ibc.OrderOrdered) && ch.Order != string(ibc.OrderUnordered) {
+				errs = append(errs, fmt.Errorf("relayer %q: invalid channel order %q", r.Name, ch.Order))
+			}
+		}
+	}
+
+	if s.Hyperlane != nil {
+		errs = append(errs, s.Hyperlane.validate(chains, rethNodes)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate ensures that every hyperlane participant and warp route resolves.
+func (h *HyperlaneSpec) validate(chains, rethNodes map[string]bool) []error {
+	var errs []error
+	if len(h.Chains) < 2 {
+		errs = append(errs, errors.New("hyperlane: at least two chains must be specified"))
+	}
+
+	participants := map[string]bool{}
+	for _, c := range h.Chains {
+		if !chains[c] && !rethNodes[c] {
+			errs = append(errs, fmt.Errorf("hyperlane: unknown chain %q", c))
+		}
+		participants[c] = true
+	}
+
+	var cosmosChain string
+	for _, r := range h.WarpRoutes {
+		if !chains[r.Cosmos] || !participants[r.Cosmos] {
+			errs = append(errs, fmt.Errorf("hyperlane: warp route cosmos chain %q must be a hyperlane chain", r.Cosmos))
+		}
+		if !rethNodes[r.EVM] || !participants[r.EVM] {
+			errs = append(errs, fmt.Errorf("hyperlane: warp route evm chain %q must be a hyperlane reth node", r.EVM))
+		}
+		// the collateral token is deployed once, so every route must share the same cosmos chain.
+		if cosmosChain != "" && r.Cosmos != cosmosChain {
+			errs = append(errs, fmt.Errorf("hyperlane: all warp routes must use the same cosmos chain, found %q and %q", cosmosChain, r.Cosmos))
+		}
+		cosmosChain = r.Cosmos
+	}
+	return errs
+}
+
+// chainID returns the chain ID of the chain, defaulting to its name.
+func (c ChainSpec) chainID() string {
+	if c.ChainID != "" {
+		return c.ChainID
+	}
+	return c.Name
+}
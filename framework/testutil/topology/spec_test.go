@@ -0,0 +1,235 @@
+package topology
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celestiaorg/tastora/framework/docker/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlSpec = `
+chains:
+  - name: celestia
+    chain_id: test
+    image:
+      repository: ghcr.io/celestiaorg/celestia-app
+      version: v5.0.10
+      uid_gid: "10001:10001"
+    validators: 2
+    full_nodes: 1
+  - name: simapp
+    image:
+      repository: ghcr.io/chatton/ibc-go-simd
+      version: v8.5.0
+      uid_gid: "0:0"
+    binary: simd
+    denom: stake
+da_networks:
+  - name: da
+    chain: celestia
+    image:
+      repository: ghcr.io/celestiaorg/celestia-node
+      version: v0.26.4
+      uid_gid: "10001:10001"
+    light_nodes: 1
+reth:
+  - name: reth0
+    chain_id: 1234
+evm_chains:
+  - name: evm0
+    reth: reth0
+    da_network: da
+relayers:
+  - name: hermes
+    chains: [celestia, simapp]
+    channels:
+      - source_port: transfer
+        dest_port: transfer
+        order: ordered
+        version: ics20-1
+hyperlane:
+  chains: [celestia, reth0]
+  warp_routes:
+    - cosmos: celestia
+      evm: reth0
+`
+
+const jsonSpec = `{
+  "chains": [
+    {"name": "celestia", "image": {"repository": "ghcr.io/celestiaorg/celestia-app", "version": "v5.0.10"}}
+  ],
+  "da_networks": [
+    {"name": "da", "chain": "celestia", "image": {"repository": "ghcr.io/celestiaorg/celestia-node", "version": "v0.26.4"}, "bridge_nodes": 2}
+  ]
+}`
+
+func TestParseYAML(t *testing.T) {
+	spec, err := Parse([]byte(yamlSpec))
+	require.NoError(t, err)
+
+	require.Len(t, spec.Chains, 2)
+	require.Equal(t, "test", spec.Chains[0].chainID())
+	require.Equal(t, "simapp", spec.Chains[1].chainID())
+	require.Equal(t, 2, spec.Chains[0].Validators)
+	require.Equal(t, 1, spec.Chains[0].FullNodes)
+	require.Equal(t, "10001:10001", spec.Chains[0].Image.Image().UIDGID)
+
+	require.Len(t, spec.DANetworks, 1)
+	require.Equal(t, 1, spec.DANetworks[0].LightNodes)
+
+	require.Equal(t, 1234, spec.Reth[0].ChainID)
+	require.Equal(t, "reth0", spec.EVMChains[0].Reth)
+
+	require.Equal(t, ibc.CreateChannelOptions{
+		SourcePortName: "transfer",
+		DestPortName:   "transfer",
+		Order:          ibc.OrderOrdered,
+		Version:        "ics20-1",
+	}, spec.Relayers[0].Channels[0].options())
+
+	require.NotNil(t, spec.Hyperlane)
+	require.Equal(t, []WarpRouteSpec{{Cosmos: "celestia", EVM: "reth0"}}, spec.Hyperlane.WarpRoutes)
+}
+
+func TestParseJSON(t *testing.T) {
+	spec, err := Parse([]byte(jsonSpec))
+	require.NoError(t, err)
+	require.Len(t, spec.Chains, 1)
+	require.Equal(t, 2, spec.DANetworks[0].BridgeNodes)
+	require.Nil(t, spec.Hyperlane)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topology.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yamlSpec), 0o600))
+
+	spec, err := LoadFile(path)
+	require.NoError(t, err)
+	require.Len(t, spec.Chains, 2)
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestValidateMultipleWarpRoutes(t *testing.T) {
+	image := ImageSpec{Repository: "repo", Version: "v1"}
+
+	spec := Spec{
+		Chains: []ChainSpec{{Name: "celestia", Image: image}},
+		Reth:   []RethSpec{{Name: "reth0"}, {Name: "reth1", ChainID: 1235}},
+		Hyperlane: &HyperlaneSpec{
+			Chains: []string{"celestia", "reth0", "reth1"},
+			WarpRoutes: []WarpRouteSpec{
+				{Cosmos: "celestia", EVM: "reth0"},
+				{Cosmos: "celestia", EVM: "reth1"},
+			},
+		},
+	}
+	require.NoError(t, spec.Validate())
+}
+
+func TestValidate(t *testing.T) {
+	image := ImageSpec{Repository: "repo", Version: "v1"}
+
+	tests := []struct {
+		name    string
+		spec    Spec
+		wantErr string
+	}{
+		{
+			name:    "no chains",
+			spec:    Spec{},
+			wantErr: "at least one chain",
+		},
+		{
+			name:    "invalid name",
+			spec:    Spec{Chains: []ChainSpec{{Name: "Celestia", Image: image}}},
+			wantErr: "lowercase alphanumeric",
+		},
+		{
+			name:    "missing image",
+			spec:    Spec{Chains: []ChainSpec{{Name: "celestia"}}},
+			wantErr: "image must be specified",
+		},
+		{
+			name: "duplicate names",
+			spec: Spec{
+				Chains: []ChainSpec{{Name: "celestia", Image: image}},
+				Reth:   []RethSpec{{Name: "celestia"}},
+			},
+			wantErr: "already used by a chain",
+		},
+		{
+			name: "duplicate chain ids",
+			spec: Spec{Chains: []ChainSpec{
+				{Name: "a", ChainID: "test", Image: image},
+				{Name: "b", ChainID: "test", Image: image},
+			}},
+			wantErr: "is not unique",
+		},
+		{
+			name: "unknown da chain",
+			spec: Spec{
+				Chains:     []ChainSpec{{Name: "celestia", Image: image}},
+				DANetworks: []DANetworkSpec{{Name: "da", Chain: "other", Image: image}},
+			},
+			wantErr: `unknown chain "other"`,
+		},
+		{
+			name: "unknown evm references",
+			spec: Spec{
+				Chains:    []ChainSpec{{Name: "celestia", Image: image}},
+				EVMChains: []EVMChainSpec{{Name: "evm", Reth: "reth", DANetwork: "da"}},
+			},
+			wantErr: `unknown reth node "reth"`,
+		},
+		{
+			name: "relayer with one chain",
+			spec: Spec{
+				Chains:   []ChainSpec{{Name: "celestia", Image: image}},
+				Relayers: []RelayerSpec{{Name: "hermes", Chains: []string{"celestia"}}},
+			},
+			wantErr: "exactly two chains",
+		},
+		{
+			name: "unsupported relayer",
+			spec: Spec{
+				Chains:   []ChainSpec{{Name: "a", Image: image}, {Name: "b", Image: image}},
+				Relayers: []RelayerSpec{{Name: "rly", Type: "go-relayer", Chains: []string{"a", "b"}}},
+			},
+			wantErr: "unsupported type",
+		},
+		{
+			name: "invalid channel order",
+			spec: Spec{
+				Chains: []ChainSpec{{Name: "a", Image: image}, {Name: "b", Image: image}},
+				Relayers: []RelayerSpec{{Name: "hermes", Chains: []string{"a", "b"}, Channels: []ChannelSpec{
+					{SourcePort: "transfer", DestPort: "transfer", Version: "ics20-1", Order: "random"},
+				}}},
+			},
+			wantErr: "invalid channel order",
+		},
+		{
+			name: "warp route to non participant",
+			spec: Spec{
+				Chains: []ChainSpec{{Name: "celestia", Image: image}},
+				Reth:   []RethSpec{{Name: "reth0"}, {Name: "reth1"}},
+				Hyperlane: &HyperlaneSpec{
+					Chains:     []string{"celestia", "reth0"},
+					WarpRoutes: []WarpRouteSpec{{Cosmos: "celestia", EVM: "reth1"}},
+				},
+			},
+			wantErr: "must be a hyperlane reth node",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
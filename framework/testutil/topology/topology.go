@@ -0,0 +1,581 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/celestiaorg/tastora/framework/docker/cosmos"
+	da "github.com/celestiaorg/tastora/framework/docker/dataavailability"
+	evmsingle "github.com/celestiaorg/tastora/framework/docker/evstack/evmsingle"
+	reth "github.com/celestiaorg/tastora/framework/docker/evstack/reth"
+	"github.com/celestiaorg/tastora/framework/docker/hyperlane"
+	"github.com/celestiaorg/tastora/framework/docker/ibc"
+	"github.com/celestiaorg/tastora/framework/docker/ibc/relayer"
+	"github.com/celestiaorg/tastora/framework/testutil/config"
+	"github.com/celestiaorg/tastora/framework/testutil/evm"
+	"github.com/celestiaorg/tastora/framework/types"
+	servercfg "github.com/cosmos/cosmos-sdk/server/config"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govmodule "github.com/cosmos/cosmos-sdk/x/gov"
+	transfer "github.com/cosmos/ibc-go/v8/modules/apps/transfer"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// daWalletFunds is the amount sent to every bridge node wallet so that it can pay for blob submissions.
+const daWalletFunds = 100_000_000_00
+
+// Config contains the docker resources shared by every component of an Environment.
+type Config struct {
+	// Logger is the logger instance used for all operations.
+	Logger *zap.Logger
+	// DockerClient is the docker client instance.
+	DockerClient types.TastoraDockerClient
+	// DockerNetworkID is the ID of the docker network every component is deployed to.
+	DockerNetworkID string
+	// TestName is used to name docker resources so that parallel tests do not collide.
+	TestName string
+}
+
+// Relayer is a started IBC relayer along with the connection and channels it created.
+type Relayer struct {
+	ibc.Relayer
+	Connection ibc.Connection
+	Channels   []ibc.Channel
+}
+
+// Hyperlane is a completed hyperlane deployment.
+type Hyperlane struct {
+	Deployer *hyperlane.Deployer
+	// Cosmos holds the cosmos-native deployment when warp routes were requested, nil otherwise.
+	Cosmos *hyperlane.CosmosConfig
+}
+
+// Environment holds typed handles to every started component, keyed by the name given in the spec.
+type Environment struct {
+	Spec       *Spec
+	Chains     map[string]*cosmos.Chain
+	DANetworks map[string]*da.Network
+	Reth       map[string]*reth.Node
+	EVMChains  map[string]*evmsingle.Chain
+	Relayers   map[string]*Relayer
+	Hyperlane  *Hyperlane
+}
+
+// Chain returns the chain with the given name.
+func (e *Environment) Chain(name string) (*cosmos.Chain, error) {
+	c, ok := e.Chains[name]
+	if !ok {
+		return nil, fmt.Errorf("chain %q not found in topology", name)
+	}
+	return c, nil
+}
+
+// DANetwork returns the DA network with the given name.
+func (e *Environment) DANetwork(name string) (*da.Network, error) {
+	n, ok := e.DANetworks[name]
+	if !ok {
+		return nil, fmt.Errorf("da network %q not found in topology", name)
+	}
+	return n, nil
+}
+
+// RethNode returns the reth node with the given name.
+func (e *Environment) RethNode(name string) (*reth.Node, error) {
+	n, ok := e.Reth[name]
+	if !ok {
+		return nil, fmt.Errorf("reth node %q not found in topology", name)
+	}
+	return n, nil
+}
+
+// EVMChain returns the evm-single chain with the given name.
+func (e *Environment) EVMChain(name string) (*evmsingle.Chain, error) {
+	c, ok := e.EVMChains[name]
+	if !ok {
+		return nil, fmt.Errorf("evm chain %q not found in topology", name)
+	}
+	return c, nil
+}
+
+// Relayer returns the relayer with the given name.
+func (e *Environment) Relayer(name string) (*Relayer, error) {
+	r, ok := e.Relayers[name]
+	if !ok {
+		return nil, fmt.Errorf("relayer %q not found in topology", name)
+	}
+	return r, nil
+}
+
+// BuildFile loads the spec at path and builds it. See Build.
+func BuildFile(ctx context.Context, t types.TestingT, cfg Config, path string) (*Environment, error) {
+	spec, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Build(ctx, t, cfg, spec)
+}
+
+// Build creates and starts every component of the spec in dependency order and wires them together.
+// Docker resources are cleaned up by the cleanup registered with docker.Setup.
+func Build(ctx context.Context, t types.TestingT, cfg Config, spec *Spec) (*Environment, error) {
+	t.Helper()
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid topology: %w", err)
+	}
+
+	env := &Environment{
+		Spec:       spec,
+		Chains:     map[string]*cosmos.Chain{},
+		DANetworks: map[string]*da.Network{},
+		Reth:       map[string]*reth.Node{},
+		EVMChains:  map[string]*evmsingle.Chain{},
+		Relayers:   map[string]*Relayer{},
+	}
+
+	if err := env.startChains(ctx, t, cfg); err != nil {
+		return nil, err
+	}
+	for _, s := range spec.DANetworks {
+		if err := env.startDANetwork(ctx, t, cfg, s); err != nil {
+			return nil, fmt.Errorf("da network %s: %w", s.Name, err)
+		}
+	}
+	for _, s := range spec.Reth {
+		if err := env.startReth(ctx, t, cfg, s); err != nil {
+			return nil, fmt.Errorf("reth node %s: %w", s.Name, err)
+		}
+	}
+	for _, s := range spec.EVMChains {
+		if err := env.startEVMChain(ctx, t, cfg, s); err != nil {
+			return nil, fmt.Errorf("evm chain %s: %w", s.Name, err)
+		}
+	}
+	for i, s := range spec.Relayers {
+		if err := env.startRelayer(ctx, cfg, i, s); err != nil {
+			return nil, fmt.Errorf("relayer %s: %w", s.Name, err)
+		}
+	}
+	if spec.Hyperlane != nil {
+		if err := env.deployHyperlane(ctx, cfg, *spec.Hyperlane); err != nil {
+			return nil, fmt.Errorf("hyperlane: %w", err)
+		}
+	}
+
+	return env, nil
+}
+
+// startChains builds every chain and starts them in parallel.
+func (e *Environment) startChains(ctx context.Context, t types.TestingT, cfg Config) error {
+	encConfig := testutil.MakeTestEncodingConfig(auth.AppModuleBasic{}, bank.AppModuleBasic{}, transfer.AppModuleBasic{}, govmodule.AppModuleBasic{})
+
+	for _, s := range e.Spec.Chains {
+		chain, err := chainBuilder(t, cfg, s, &encConfig).Build(ctx)
+		if err != nil {
+			return fmt.Errorf("build chain %s: %w", s.Name, err)
+		}
+		e.Chains[s.Name] = chain
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for name, chain := range e.Chains {
+		eg.Go(func() error {
+			if err := chain.Start(egCtx); err != nil {
+				return fmt.Errorf("start chain %s: %w", name, err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// chainBuilder returns a builder for the chain described by s.
+func chainBuilder(t types.TestingT, cfg Config, s ChainSpec, encConfig *testutil.TestEncodingConfig) *cosmos.ChainBuilder {
+	binary := valueOrDefault(s.Binary, "celestia-appd")
+	denom := valueOrDefault(s.Denom, "utia")
+	minGasPrices := valueOrDefault(s.MinGasPrices, "0"+denom)
+
+	var nodes []cosmos.ChainNodeConfig
+	for i := 0; i < valueOrDefault(s.Validators, 1); i++ {
+		nodes = append(nodes, cosmos.NewChainNodeConfigBuilder().Build())
+	}
+	for i := 0; i < s.FullNodes; i++ {
+		nodes = append(nodes, cosmos.NewChainNodeConfigBuilder().WithNodeType(types.NodeTypeConsensusFull).Build())
+	}
+
+	return cosmos.NewChainBuilderWithTestName(t, cfg.TestName).
+		WithLogger(cfg.Logger).
+		WithDockerClient(cfg.DockerClient).
+		WithDockerNetworkID(cfg.DockerNetworkID).
+		WithName(s.Name).
+		WithChainID(s.chainID()).
+		WithImage(s.Image.Image()).
+		WithBinaryName(binary).
+		WithBech32Prefix(valueOrDefault(s.Bech32Prefix, "celestia")).
+		WithDenom(denom).
+		WithGasPrices(valueOrDefault(s.GasPrices, "0.025"+denom)).
+		WithEncodingConfig(encConfig).
+		WithAdditionalStartArgs(s.StartArgs...).
+		WithEnv(s.Env...).
+		WithPostInit(func(ctx context.Context, node *cosmos.ChainNode) error {
+			return config.Modify(ctx, node, "config/app.toml", func(appCfg *servercfg.Config) {
+				appCfg.MinGasPrices = minGasPrices
+				appCfg.GRPC.Enable = true
+				appCfg.GRPC.Address = "0.0.0.0:9090"
+				appCfg.API.Enable = true
+			})
+		}).
+		WithNodes(nodes...)
+}
+
+// startDANetwork builds the DA network, starts its bridge nodes against the chain, funds them and
+// then starts the light nodes connected to the first bridge node.
+func (e *Environment) startDANetwork(ctx context.Context, t types.TestingT, cfg Config, s DANetworkSpec) error {
+	chain := e.Chains[s.Chain]
+	chainID := chain.GetChainID()
+
+	var nodes []da.NodeConfig
+	for i := 0; i < valueOrDefault(s.BridgeNodes, 1); i++ {
+		nodes = append(nodes, da.NewNodeBuilder().WithNodeType(types.BridgeNode).Build())
+	}
+	for i := 0; i < s.LightNodes; i++ {
+		nodes = append(nodes, da.NewNodeBuilder().WithNodeType(types.LightNode).Build())
+	}
+
+	// DA node names are derived from the test name, which is scoped to the network so that
+	// several networks can coexist.
+	network, err := da.NewNetworkBuilderWithTestName(t, fmt.Sprintf("%s-%s", s.Name, cfg.TestName)).
+		WithLogger(cfg.Logger).
+		WithDockerClient(cfg.DockerClient).
+		WithDockerNetworkID(cfg.DockerNetworkID).
+		WithChainID(chainID).
+		WithImage(s.Image.Image()).
+		WithNodes(nodes...).
+		Build(ctx)
+	if err != nil {
+		return fmt.Errorf("build: %w", err)
+	}
+
+	cni, err := chain.GetNodes()[0].GetNetworkInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("chain network info: %w", err)
+	}
+	genesisHash, err := genesisHash(ctx, chain)
+	if err != nil {
+		return err
+	}
+
+	bridges := network.GetBridgeNodes()
+	for _, bridge := range bridges {
+		if err := bridge.Start(ctx,
+			da.WithChainID(chainID),
+			da.WithAdditionalStartArguments("--p2p.network", chainID, "--core.ip", cni.Internal.Hostname, "--rpc.addr", "0.0.0.0"),
+			da.WithEnvironmentVariables(map[string]string{
+				"CELESTIA_CUSTOM": types.BuildCelestiaCustomEnvVar(chainID, genesisHash, ""),
+				"P2P_NETWORK":     chainID,
+			}),
+		); err != nil {
+			return fmt.Errorf("start bridge node %s: %w", bridge.Name(), err)
+		}
+		if err := fundWallet(ctx, chain, bridge); err != nil {
+			return err
+		}
+	}
+
+	if lights := network.GetLightNodes(); len(lights) > 0 {
+		p2pInfo, err := bridges[0].GetP2PInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("bridge node p2p info: %w", err)
+		}
+		p2pAddr, err := p2pInfo.GetP2PAddress()
+		if err != nil {
+			return fmt.Errorf("bridge node p2p address: %w", err)
+		}
+		for _, light := range lights {
+			if err := light.Start(ctx,
+				da.WithChainID(chainID),
+				da.WithAdditionalStartArguments("--p2p.network", chainID, "--rpc.addr", "0.0.0.0"),
+				da.WithEnvironmentVariables(map[string]string{
+					"CELESTIA_CUSTOM": types.BuildCelestiaCustomEnvVar(chainID, genesisHash, p2pAddr),
+					"P2P_NETWORK":     chainID,
+				}),
+			); err != nil {
+				return fmt.Errorf("start light node %s: %w", light.Name(), err)
+			}
+		}
+	}
+
+	e.DANetworks[s.Name] = network
+	return nil
+}
+
+// fundWallet sends funds from the chain faucet to the wallet of the DA node.
+func fundWallet(ctx context.Context, chain *cosmos.Chain, node *da.Node) error {
+	wallet, err := node.GetWallet()
+	if err != nil {
+		return fmt.Errorf("get wallet of %s: %w", node.Name(), err)
+	}
+	from, err := sdk.AccAddressFromBech32(chain.GetFaucetWallet().GetFormattedAddress())
+	if err != nil {
+		return fmt.Errorf("faucet address: %w", err)
+	}
+	to, err := sdk.AccAddressFromBech32(wallet.GetFormattedAddress())
+	if err != nil {
+		return fmt.Errorf("address of %s: %w", node.Name(), err)
+	}
+	send := banktypes.NewMsgSend(from, to, sdk.NewCoins(sdk.NewCoin(chain.Config.Denom, sdkmath.NewInt(daWalletFunds))))
+	if _, err := chain.BroadcastMessages(ctx, chain.GetFaucetWallet(), send); err != nil {
+		return fmt.Errorf("fund wallet of %s: %w", node.Name(), err)
+	}
+	return nil
+}
+
+// startReth builds and starts the reth node.
+func (e *Environment) startReth(ctx context.Context, t types.TestingT, cfg Config, s RethSpec) error {
+	var genesis []byte
+	if s.GenesisFile != "" {
+		bz, err := os.ReadFile(s.GenesisFile)
+		if err != nil {
+			return fmt.Errorf("read genesis file: %w", err)
+		}
+		genesis = bz
+	} else {
+		var opts []reth.GenesisOpt
+		if s.ChainID != 0 {
+			opts = append(opts, reth.WithChainID(s.ChainID))
+		}
+		genesis = []byte(reth.DefaultEvolveGenesisJSON(opts...))
+	}
+
+	b := reth.NewNodeBuilderWithTestName(t, cfg.TestName).
+		WithLogger(cfg.Logger).
+		WithDockerClient(cfg.DockerClient).
+		WithDockerNetworkID(cfg.DockerNetworkID).
+		WithName(s.Name).
+		WithGenesis(genesis).
+		WithHyperlaneChainName(s.Name)
+	if s.Image.isSet() {
+		b = b.WithImage(s.Image.Image())
+	}
+	if s.ChainID != 0 {
+		b = b.WithHyperlaneChainID(uint64(s.ChainID))
+	}
+	if domainID := valueOrDefault(s.DomainID, uint32(s.ChainID)); domainID != 0 {
+		b = b.WithHyperlaneDomainID(domainID)
+	}
+
+	node, err := b.Build(ctx)
+	if err != nil {
+		return fmt.Errorf("build: %w", err)
+	}
+	if err := node.Start(ctx); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	e.Reth[s.Name] = node
+	return nil
+}
+
+// startEVMChain builds and starts an evm-single aggregator backed by its reth node and DA network.
+func (e *Environment) startEVMChain(ctx context.Context, t types.TestingT, cfg Config, s EVMChainSpec) error {
+	rnode := e.Reth[s.Reth]
+	bridge := e.DANetworks[s.DANetwork].GetBridgeNodes()[0]
+
+	bni, err := bridge.GetNetworkInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("bridge network info: %w", err)
+	}
+	rni, err := rnode.GetNetworkInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("reth network info: %w", err)
+	}
+	rGenesisHash, err := rnode.GenesisHash(ctx)
+	if err != nil {
+		return fmt.Errorf("reth genesis hash: %w", err)
+	}
+
+	nodeCfg := evmsingle.NewNodeConfigBuilder().
+		WithEVMEngineURL(fmt.Sprintf("http://%s:%s", rni.Internal.Hostname, rni.Internal.Ports.Engine)).
+		WithEVMETHURL(fmt.Sprintf("http://%s:%s", rni.Internal.Hostname, rni.Internal.Ports.RPC)).
+		WithEVMJWTSecret(rnode.JWTSecretHex()).
+		WithEVMSignerPassphrase("secret").
+		WithEVMBlockTime(valueOrDefault(s.BlockTime, "1s")).
+		WithEVMGenesisHash(rGenesisHash).
+		WithDAAddress(fmt.Sprintf("http://%s:%s", bni.Internal.IP, bni.Internal.Ports.RPC)).
+		Build()
+
+	b := evmsingle.NewChainBuilderWithTestName(t, cfg.TestName).
+		WithLogger(cfg.Logger).
+		WithDockerClient(cfg.DockerClient).
+		WithDockerNetworkID(cfg.DockerNetworkID).
+		WithName(s.Name).
+		WithNodes(nodeCfg)
+	if s.Image.isSet() {
+		b = b.WithImage(s.Image.Image())
+	}
+
+	chain, err := b.Build(ctx)
+	if err != nil {
+		return fmt.Errorf("build: %w", err)
+	}
+	if err := chain.Start(ctx); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	e.EVMChains[s.Name] = chain
+	return nil
+}
+
+// startRelayer creates the relayer, establishes a connection and the requested channels and starts relaying.
+func (e *Environment) startRelayer(ctx context.Context, cfg Config, index int, s RelayerSpec) error {
+	chainA, chainB := e.Chains[s.Chains[0]], e.Chains[s.Chains[1]]
+
+	var (
+		rly ibc.Relayer
+		err error
+	)
+	if s.Type == RelayerTypeGoRelayer {
+		rly, err = relayer.NewGoRelayer(ctx, cfg.DockerClient, cfg.TestName, cfg.DockerNetworkID, index, cfg.Logger)
+	} else {
+		rly, err = relayer.NewHermes(ctx, cfg.DockerClient, cfg.TestName, cfg.DockerNetworkID, index, cfg.Logger)
+	}
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	if err := rly.Init(ctx, []types.Chain{chainA, chainB}); err != nil {
+		return fmt.Errorf("init: %w", err)
+	}
+	if err := rly.CreateClients(ctx, chainA, chainB); err != nil {
+		return fmt.Errorf("create clients: %w", err)
+	}
+	connection, err := rly.CreateConnections(ctx, chainA, chainB)
+	if err != nil {
+		return fmt.Errorf("create connection: %w", err)
+	}
+
+	channelSpecs := s.Channels
+	if len(channelSpecs) == 0 {
+		channelSpecs = []ChannelSpec{{SourcePort: "transfer", DestPort: "transfer", Version: "ics20-1"}}
+	}
+	var channels []ibc.Channel
+	for _, cs := range channelSpecs {
+		channel, err := rly.CreateChannel(ctx, chainA, connection, cs.options())
+		if err != nil {
+			return fmt.Errorf("create channel %s/%s: %w", cs.SourcePort, cs.DestPort, err)
+		}
+		channels = append(channels, channel)
+	}
+
+	if err := rly.Start(ctx); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	e.Relayers[s.Name] = &Relayer{
+		Relayer:    rly,
+		Connection: connection,
+		Channels:   channels,
+	}
+	return nil
+}
+
+// deployHyperlane deploys hyperlane to every listed chain and enrolls the requested warp routes.
+func (e *Environment) deployHyperlane(ctx context.Context, cfg Config, s HyperlaneSpec) error {
+	var providers []hyperlane.ChainConfigProvider
+	for _, name := range s.Chains {
+		if chain, ok := e.Chains[name]; ok {
+			providers = append(providers, chain)
+			continue
+		}
+		providers = append(providers, e.Reth[name])
+	}
+
+	image := hyperlane.DefaultDeployerImage()
+	if s.Image.isSet() {
+		image = s.Image.Image()
+	}
+
+	d, err := hyperlane.NewDeployer(ctx, hyperlane.Config{
+		Logger:          cfg.Logger,
+		DockerClient:    cfg.DockerClient,
+		DockerNetworkID: cfg.DockerNetworkID,
+		HyperlaneImage:  image,
+	}, cfg.TestName, providers)
+	if err != nil {
+		return fmt.Errorf("create deployer: %w", err)
+	}
+	if err := d.Deploy(ctx); err != nil {
+		return fmt.Errorf("deploy: %w", err)
+	}
+
+	e.Hyperlane = &Hyperlane{Deployer: d}
+	if len(s.WarpRoutes) == 0 {
+		return nil
+	}
+
+	chain := e.Chains[s.WarpRoutes[0].Cosmos]
+	broadcaster := cosmos.NewBroadcaster(chain)
+	faucet := chain.GetFaucetWallet()
+
+	cosmosCfg, err := d.DeployCosmosNoopISM(ctx, broadcaster, faucet)
+	if err != nil {
+		return fmt.Errorf("deploy cosmos stack: %w", err)
+	}
+	e.Hyperlane.Cosmos = cosmosCfg
+
+	entry, err := chain.GetHyperlaneRegistryEntry(ctx)
+	if err != nil {
+		return fmt.Errorf("cosmos registry entry: %w", err)
+	}
+	for _, route := range s.WarpRoutes {
+		rnode := e.Reth[route.EVM]
+		tokenRouter, err := d.GetEVMWarpTokenAddressForChain(ctx, rnode.HyperlaneChainName())
+		if err != nil {
+			return fmt.Errorf("evm warp token address of %s: %w", route.EVM, err)
+		}
+
+		rni, err := rnode.GetNetworkInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("reth network info: %w", err)
+		}
+		rpcURL := fmt.Sprintf("http://%s", rni.External.RPCAddress())
+
+		if _, err := d.EnrollRemoteRouter(ctx, tokenRouter.Hex(), entry.Metadata.DomainID, cosmosCfg.TokenID.String(), rnode.HyperlaneChainName(), rpcURL); err != nil {
+			return fmt.Errorf("enroll router on %s: %w", route.EVM, err)
+		}
+		if err := d.EnrollRemoteRouterOnCosmos(ctx, broadcaster, faucet, cosmosCfg.TokenID, rnode.HyperlaneDomainID(), evm.PadAddress(tokenRouter).String()); err != nil {
+			return fmt.Errorf("enroll router for %s on %s: %w", route.EVM, route.Cosmos, err)
+		}
+	}
+	return nil
+}
+
+// genesisHash returns the hash of the first block of the chain.
+func genesisHash(ctx context.Context, chain *cosmos.Chain) (string, error) {
+	c, err := chain.GetNodes()[0].GetRPCClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to get node client: %w", err)
+	}
+
+	first := int64(1)
+	block, err := c.Block(ctx, &first)
+	if err != nil {
+		return "", fmt.Errorf("failed to get genesis block: %w", err)
+	}
+	return block.Block.Header.Hash().String(), nil
+}
+
+// valueOrDefault returns v, or def if v is the zero value.
+func valueOrDefault[T comparable](v, def T) T {
+	var zero T
+	if v == zero {
+		return def
+	}
+	return v
+}
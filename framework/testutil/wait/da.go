@@ -0,0 +1,116 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/celestiaorg/tastora/framework/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// HeaderSubscriber streams the headers synced by a node as they arrive (implemented by DA nodes)
+type HeaderSubscriber interface {
+	GetLocalHead(ctx context.Context) (types.Header, error)
+	SubscribeHeaders(ctx context.Context) (<-chan types.Header, error)
+}
+
+// ForDAHeight blocks until the node has synced the header at the target height.
+// New headers are received through a subscription instead of polling the node.
+func ForDAHeight(ctx context.Context, node HeaderSubscriber, targetHeight uint64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscribe before reading the local head so that no header is missed in between.
+	headers, err := node.SubscribeHeaders(ctx)
+	if err != nil {
+		return err
+	}
+
+	head, err := node.GetLocalHead(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get local head: %w", err)
+	}
+	if head.Height >= targetHeight {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for node to reach height %d: %w", targetHeight, ctx.Err())
+		case h, ok := <-headers:
+			if !ok {
+				return fmt.Errorf("header subscription closed before reaching height %d", targetHeight)
+			}
+			if h.Height >= targetHeight {
+				return nil
+			}
+		}
+	}
+}
+
+// ForNewDAHeaders blocks until the node has synced n new headers and returns the last of them.
+func ForNewDAHeaders(ctx context.Context, node HeaderSubscriber, n int) (types.Header, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	headers, err := node.SubscribeHeaders(ctx)
+	if err != nil {
+		return types.Header{}, err
+	}
+
+	var last types.Header
+	for received := 0; received < n; {
+		select {
+		case <-ctx.Done():
+			return types.Header{}, fmt.Errorf("timed out after %d of %d new headers: %w", received, n, ctx.Err())
+		case h, ok := <-headers:
+			if !ok {
+				return types.Header{}, fmt.Errorf("header subscription closed after %d of %d new headers", received, n)
+			}
+			last = h
+			received++
+		}
+	}
+	return last, nil
+}
+
+// ForDANodesInSync blocks until every node has synced the highest local head among the nodes at the time
+// of the call, and returns its height. This ensures that all nodes agree on a common head.
+func ForDANodesInSync(ctx context.Context, nodes ...HeaderSubscriber) (uint64, error) {
+	if len(nodes) == 0 {
+		panic("missing nodes")
+	}
+
+	heads := make([]uint64, len(nodes))
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, n := range nodes {
+		eg.Go(func() error {
+			head, err := n.GetLocalHead(egCtx)
+			if err != nil {
+				return fmt.Errorf("failed to get local head: %w", err)
+			}
+			heads[i] = head.Height
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return 0, err
+	}
+
+	var target uint64
+	for _, h := range heads {
+		target = max(target, h)
+	}
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range nodes {
+		eg.Go(func() error {
+			return ForDAHeight(egCtx, n, target)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return 0, err
+	}
+	return target, nil
+}
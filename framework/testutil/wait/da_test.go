@@ -0,0 +1,105 @@
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/tastora/framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHeaderSubscriber has a fixed local head and streams headers from the head onwards, one per tick.
+type mockHeaderSubscriber struct {
+	head    uint64
+	headers int
+}
+
+func (m *mockHeaderSubscriber) GetLocalHead(context.Context) (types.Header, error) {
+	return types.Header{Height: m.head}, nil
+}
+
+func (m *mockHeaderSubscriber) SubscribeHeaders(ctx context.Context) (<-chan types.Header, error) {
+	ch := make(chan types.Header)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= m.headers; i++ {
+			select {
+			case ch <- types.Header{Height: m.head + uint64(i)}:
+			case <-ctx.Done():
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	return ch, nil
+}
+
+func TestForDAHeight(t *testing.T) {
+	t.Parallel()
+
+	t.Run("already reached", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, ForDAHeight(context.Background(), &mockHeaderSubscriber{head: 10}, 5))
+	})
+
+	t.Run("reached through subscription", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, ForDAHeight(context.Background(), &mockHeaderSubscriber{head: 10, headers: 5}, 15))
+	})
+
+	t.Run("subscription closed", func(t *testing.T) {
+		t.Parallel()
+		err := ForDAHeight(context.Background(), &mockHeaderSubscriber{head: 10, headers: 2}, 15)
+		require.ErrorContains(t, err, "subscription closed")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.Error(t, ForDAHeight(ctx, &mockHeaderSubscriber{head: 10}, 15))
+	})
+
+	t.Run("used by ForDANodeToReachHeight", func(t *testing.T) {
+		t.Parallel()
+		node := &mockDANode{mockHeaderSubscriber: mockHeaderSubscriber{head: 10, headers: 5}}
+		require.NoError(t, ForDANodeToReachHeight(context.Background(), node, 15, time.Second))
+	})
+}
+
+// mockDANode is a HeaderGetter which also supports subscriptions, GetHeader always fails so that a
+// successful wait must have used the subscription.
+type mockDANode struct {
+	mockHeaderSubscriber
+}
+
+func (m *mockDANode) GetHeader(context.Context, uint64) (types.Header, error) {
+	return types.Header{}, context.DeadlineExceeded
+}
+
+func TestForNewDAHeaders(t *testing.T) {
+	t.Parallel()
+
+	h, err := ForNewDAHeaders(context.Background(), &mockHeaderSubscriber{head: 10, headers: 5}, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(13), h.Height)
+
+	_, err = ForNewDAHeaders(context.Background(), &mockHeaderSubscriber{head: 10, headers: 1}, 3)
+	require.Error(t, err)
+}
+
+func TestForDANodesInSync(t *testing.T) {
+	t.Parallel()
+
+	ahead := &mockHeaderSubscriber{head: 12}
+	behind := &mockHeaderSubscriber{head: 9, headers: 5}
+
+	height, err := ForDANodesInSync(context.Background(), ahead, behind)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), height)
+
+	require.Panics(t, func() {
+		_, _ = ForDANodesInSync(context.Background())
+	})
+}
@@ -18,7 +18,15 @@ type HeaderGetter interface {
 	GetHeader(ctx context.Context, height uint64) (types.Header, error)
 }
 
+// HeightSubscriber is a Heighter which streams the heights of new blocks as they are committed (implemented by
+// cosmos chains and chain nodes).
+type HeightSubscriber interface {
+	Heighter
+	SubscribeHeights(ctx context.Context) (<-chan int64, error)
+}
+
 // ForBlocks blocks until all chains reach a block height delta equal to or greater than the delta argument.
+// Chains implementing HeightSubscriber are waited for through a subscription to new blocks, other chains are polled.
 // If a Heighter does not monotonically increase the height, this function may block program execution indefinitely.
 func ForBlocks(ctx context.Context, delta int, chains ...Heighter) error {
 	if len(chains) == 0 {
@@ -28,6 +36,9 @@ func ForBlocks(ctx context.Context, delta int, chains ...Heighter) error {
 	for i := range chains {
 		chain := chains[i]
 		eg.Go(func() error {
+			if s, ok := chain.(HeightSubscriber); ok {
+				return forDeltaSubscribed(egCtx, s, delta)
+			}
 			h := &height{Chain: chain}
 			return h.ForDelta(egCtx, delta)
 		})
@@ -35,6 +46,39 @@ func ForBlocks(ctx context.Context, delta int, chains ...Heighter) error {
 	return eg.Wait()
 }
 
+// forDeltaSubscribed blocks until the chain has committed delta blocks after its current height, falling back
+// to polling the height if the subscription to new blocks cannot be established.
+func forDeltaSubscribed(ctx context.Context, chain HeightSubscriber, delta int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// subscribe before reading the current height so that no block is missed in between.
+	heights, err := chain.SubscribeHeights(ctx)
+	if err != nil {
+		h := &height{Chain: chain}
+		return h.ForDelta(ctx, delta)
+	}
+
+	start, err := chain.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get height: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case cur, ok := <-heights:
+			if !ok {
+				return fmt.Errorf("block subscription closed before %d blocks after height %d", delta, start)
+			}
+			if cur-start >= int64(delta) {
+				return nil
+			}
+		}
+	}
+}
+
 // ForBlocksUtil iterates from 0 to maxBlocks and calls fn function with the current iteration index as a parameter.
 // If fn returns nil, the loop is terminated and the function returns nil.
 // If fn returns an error and the loop has iterated over all maxBlocks without success, the error is returned.
@@ -165,12 +209,17 @@ func ForCondition(ctx context.Context, timeoutAfter, pollingInterval time.Durati
 }
 
 // ForDANodeToReachHeight waits for a data availability node to reach a target block height within a given context.
-// It periodically checks the node's current height and returns nil when the target height is reached.
+// If the node implements HeaderSubscriber new headers are received through a subscription, otherwise it
+// periodically checks the node's current height. It returns nil when the target height is reached.
 // Returns an error if the context times out or if retrieving the header fails persistently.
 func ForDANodeToReachHeight(ctx context.Context, hg HeaderGetter, targetHeight uint64, timeout time.Duration) error {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	if sub, ok := hg.(HeaderSubscriber); ok {
+		return ForDAHeight(ctx, sub, targetHeight)
+	}
+
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
@@ -181,3 +181,54 @@ func TestWaitForInSync(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// mockHeightSubscriber has a fixed height and streams the heights of new blocks, one per tick.
+type mockHeightSubscriber struct {
+	mockChainHeighterFixed
+	blocks       int
+	subscribeErr error
+}
+
+func (m *mockHeightSubscriber) SubscribeHeights(ctx context.Context) (<-chan int64, error) {
+	if m.subscribeErr != nil {
+		return nil, m.subscribeErr
+	}
+	ch := make(chan int64)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= m.blocks; i++ {
+			select {
+			case ch <- m.CurHeight + int64(i):
+			case <-ctx.Done():
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	return ch, nil
+}
+
+func TestWaitForBlocksSubscribed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reached through subscription", func(t *testing.T) {
+		t.Parallel()
+		chain := &mockHeightSubscriber{mockChainHeighterFixed: mockChainHeighterFixed{CurHeight: 10}, blocks: 5}
+		require.NoError(t, ForBlocks(context.Background(), 3, chain))
+	})
+
+	t.Run("subscription closed", func(t *testing.T) {
+		t.Parallel()
+		chain := &mockHeightSubscriber{mockChainHeighterFixed: mockChainHeighterFixed{CurHeight: 10}, blocks: 2}
+		require.ErrorContains(t, ForBlocks(context.Background(), 3, chain), "subscription closed")
+	})
+
+	t.Run("falls back to polling", func(t *testing.T) {
+		t.Parallel()
+		chain := &mockHeightSubscriber{subscribeErr: errors.New("no websocket")}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		// the fixed height never increases, so polling times out.
+		require.ErrorIs(t, ForBlocks(ctx, 1, chain), context.DeadlineExceeded)
+	})
+}
@@ -18,6 +18,9 @@ type ChainRelayerConfig struct {
 	Bech32Prefix string
 	RPCAddress   string
 	GRPCAddress  string
+	// TrustingPeriod is the trusting period of the light clients of the chain created by relayers, e.g. "2m".
+	// Relayers use their default if it is empty.
+	TrustingPeriod string
 }
 
 type Chain interface {
@@ -53,6 +53,7 @@ type Blob struct {
 	Data         string `json:"data"`
 	ShareVersion int    `json:"share_version"`
 	Commitment   string `json:"commitment"`
+	Signer       string `json:"signer,omitempty"`
 	Index        int    `json:"index"`
 }
 
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// TestingT is the subset of testing.TB used by the builders. *testing.T, *testing.B and *testing.F
+// all satisfy it, and StandaloneT provides an implementation for programs which are not tests.
+type TestingT interface {
+	Helper()
+	Name() string
+	Cleanup(func())
+
+	Logf(format string, args ...any)
+	Errorf(format string, args ...any)
+
+	Fail()
+	FailNow()
+	Failed() bool
+}
+
+var _ TestingT = (*StandaloneT)(nil)
+
+// StandaloneT implements TestingT outside of go test, e.g. in a CLI or a long-running program.
+// Log output is written to the provided logger and cleanup functions are only run when Close is called.
+type StandaloneT struct {
+	name string
+	log  *zap.Logger
+
+	mu       sync.Mutex
+	failed   bool
+	cleanups []func()
+}
+
+// NewStandaloneT returns a StandaloneT with the given name, which is used to name docker resources.
+func NewStandaloneT(name string, logger *zap.Logger) *StandaloneT {
+	return &StandaloneT{
+		name: name,
+		log:  logger,
+	}
+}
+
+// Helper is a no-op as there is no test output to attribute lines to.
+func (t *StandaloneT) Helper() {}
+
+// Name returns the name the StandaloneT was created with.
+func (t *StandaloneT) Name() string {
+	return t.name
+}
+
+// Cleanup registers fn to be run by Close.
+func (t *StandaloneT) Cleanup(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cleanups = append(t.cleanups, fn)
+}
+
+// Close runs every registered cleanup function in the reverse order of registration.
+func (t *StandaloneT) Close() {
+	t.mu.Lock()
+	cleanups := t.cleanups
+	t.cleanups = nil
+	t.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+// Logf logs the formatted message at info level.
+func (t *StandaloneT) Logf(format string, args ...any) {
+	t.log.Info(fmt.Sprintf(format, args...))
+}
+
+// Errorf logs the formatted message at error level and marks the StandaloneT as failed.
+func (t *StandaloneT) Errorf(format string, args ...any) {
+	t.log.Error(fmt.Sprintf(format, args...))
+	t.Fail()
+}
+
+// Fail marks the StandaloneT as failed.
+func (t *StandaloneT) Fail() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failed = true
+}
+
+// FailNow marks the StandaloneT as failed, runs the registered cleanup functions and exits the process.
+func (t *StandaloneT) FailNow() {
+	t.Fail()
+	t.Close()
+	os.Exit(1)
+}
+
+// Failed reports whether the StandaloneT has been marked as failed.
+func (t *StandaloneT) Failed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failed
+}
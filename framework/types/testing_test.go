@@ -0,0 +1,35 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// the builders must be usable from tests, benchmarks and fuzz targets.
+var (
+	_ TestingT = (*testing.T)(nil)
+	_ TestingT = (*testing.B)(nil)
+	_ TestingT = (*testing.F)(nil)
+)
+
+func TestStandaloneT(t *testing.T) {
+	st := NewStandaloneT("standalone", zap.NewNop())
+	require.Equal(t, "standalone", st.Name())
+	require.False(t, st.Failed())
+
+	var order []int
+	st.Cleanup(func() { order = append(order, 1) })
+	st.Cleanup(func() { order = append(order, 2) })
+	require.Empty(t, order, "cleanups should only run on close")
+
+	st.Close()
+	require.Equal(t, []int{2, 1}, order)
+
+	st.Close()
+	require.Equal(t, []int{2, 1}, order, "cleanups should only run once")
+
+	st.Errorf("something went wrong: %d", 1)
+	require.True(t, st.Failed())
+}